@@ -0,0 +1,60 @@
+package gojtp
+
+import "fmt"
+
+// WithRejectBidiControls Option
+// Rejects any string, key or value, containing a Unicode bidirectional
+// control character, U+200E, U+200F, U+202A to U+202E or U+2066 to
+// U+2069, either raw or as an escape sequence, as they reorder the
+// text displayed to a reader, the Trojan Source attack, with
+// jtp.bidiControlCharacter.CodePoint-[U+202E].Path-[/a], the path of
+// the value or of the value of the key.
+func WithRejectBidiControls() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectBidiControls = true
+		return nil
+	}
+}
+
+// isBidiControl reports if r is a bidirectional control character.
+func isBidiControl(r rune) bool {
+	return r == 0x200E || r == 0x200F || r >= 0x202A && r <= 0x202E ||
+		r >= 0x2066 && r <= 0x2069
+}
+
+// bidiHandler rejects the strings with a bidirectional control
+// character, forwarding the events to next if set.
+type bidiHandler struct {
+	pathForwarder
+}
+
+// check fails if the valid string span has a bidirectional control.
+func (h *bidiHandler) check(data []byte, startIndex, endIndex int) error {
+	for i := startIndex + 1; i < endIndex-1; {
+		r, size := decodeStringRune(data, i)
+		if isBidiControl(r) {
+			return fmt.Errorf("jtp.bidiControlCharacter.CodePoint-[%U]."+
+				"Path-[%s]", r, h.appendPointer(nil))
+		}
+		i += size
+	}
+	return nil
+}
+
+func (h *bidiHandler) key(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.key(data, startIndex, endIndex)
+	if err := h.check(data, startIndex, endIndex); err != nil {
+		return err
+	}
+	return h.forwarder.key(data, startIndex, endIndex)
+}
+
+func (h *bidiHandler) value(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.next()
+	if data[startIndex] == '"' {
+		if err := h.check(data, startIndex, endIndex); err != nil {
+			return err
+		}
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
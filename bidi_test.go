@@ -0,0 +1,48 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRejectBidiControls(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectBidiControls())
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "right-to-left override",
+			json: "{\"users\": [{\"name\": \"admin\u202e \u2066// check\u2069\"}]}",
+			err:  fmt.Errorf("jtp.bidiControlCharacter.CodePoint-[U+202E].Path-[/users/0/name]"),
+			ok:   false,
+		},
+		{
+			name: "escaped isolate",
+			json: `["ok", "a\u2067b"]`,
+			err:  fmt.Errorf("jtp.bidiControlCharacter.CodePoint-[U+2067].Path-[/1]"),
+			ok:   false,
+		},
+		{
+			name: "key",
+			json: "{\"a\": {\"is\u200fadmin\": true}}",
+			err:  fmt.Errorf("jtp.bidiControlCharacter.CodePoint-[U+200F].Path-[/a/is\u200fadmin]"),
+			ok:   false,
+		},
+		{
+			name: "normal strings",
+			json: `{"name": "مرحبا hello", "dash": "a\u2010b", "n": 1}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package gojtp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// VerifyAndCanonicalize returns, when the json is valid and is JSON
+// THREAT Protection Safe, its RFC 8785 JSON Canonicalization Scheme
+// (JCS) form, e.g. to sign it: no whitespace, the object members
+// sorted by the UTF-16 code units of their key, the numbers
+// formatted as ECMAScript does and the strings minimally escaped.
+//
+// A number out of the float64 range fails with numberOverflow, as
+// JCS requires IEEE-754 double values. Duplicate keys are kept.
+// https://tools.ietf.org/html/rfc8785
+func (v Verify) VerifyAndCanonicalize(json []byte) ([]byte, bool, error) {
+	if ok, err := v.VerifyBytes(json); !ok || err != nil {
+		return nil, ok, err
+	}
+	c := canonicalizer{data: json}
+	dst, err := c.appendValue(nil, skipSpace(json, 0))
+	if err != nil {
+		return nil, false, err
+	}
+	return dst, true, nil
+}
+
+// WithMaxCanonicalSize Option
+// Specifies the maximum size in bytes of the JCS form of the JSON,
+// see VerifyAndCanonicalize, i.e. of its content regardless of the
+// whitespace and of the escaping, measured while walking the JSON
+// without writing the JCS form. A number out of the float64 range
+// counts as written.
+// zero value disable the checks
+func WithMaxCanonicalSize(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max canonical size cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxCanonicalSize = l
+		verifier.canonicalSizeEnabled = true
+		return nil
+	}
+}
+
+// canonicalSizeHandler adds up the JCS size of the events of a walk,
+// forwarding them to next if set.
+type canonicalSizeHandler struct {
+	forwarder
+	max  int
+	size int
+	// open has the open containers, with their values or keys count.
+	open []canonicalFrame
+	buf  []byte
+}
+
+type canonicalFrame struct {
+	object bool
+	count  int
+}
+
+// comma returns the size of the comma before a value of an array or
+// a key of an object, if any.
+func (h *canonicalSizeHandler) comma(key bool) int {
+	k := len(h.open) - 1
+	if k < 0 || h.open[k].object != key {
+		return 0
+	}
+	if h.open[k].count++; h.open[k].count > 1 {
+		return 1
+	}
+	return 0
+}
+
+// add adds n bytes for the token at offset i.
+func (h *canonicalSizeHandler) add(n int, i int) error {
+	if h.size += n; h.size > h.max {
+		return newLimitError(canonicalSize, h.max, h.size, i)
+	}
+	return nil
+}
+
+func (h *canonicalSizeHandler) begin(c byte, i int) error {
+	if err := h.add(h.comma(false)+1, i); err != nil {
+		return err
+	}
+	h.open = append(h.open, canonicalFrame{object: c == '{'})
+	return h.forwarder.begin(c, i)
+}
+
+func (h *canonicalSizeHandler) end(c byte, i int) error {
+	h.open = h.open[:len(h.open)-1]
+	if err := h.add(1, i); err != nil {
+		return err
+	}
+	return h.forwarder.end(c, i)
+}
+
+func (h *canonicalSizeHandler) key(data []byte, startIndex, endIndex int) error {
+	// the key and its colon
+	n := h.comma(true) + canonicalStringLen(data, startIndex, endIndex) + 1
+	if err := h.add(n, startIndex); err != nil {
+		return err
+	}
+	return h.forwarder.key(data, startIndex, endIndex)
+}
+
+func (h *canonicalSizeHandler) value(data []byte, startIndex, endIndex int) error {
+	n := endIndex - startIndex
+	switch data[startIndex] {
+	case '"':
+		n = canonicalStringLen(data, startIndex, endIndex)
+	case 't', 'f', 'n':
+	default:
+		token := data[startIndex:endIndex]
+		if f, err := strconv.ParseFloat(string(token), 64); err == nil {
+			h.buf = appendCanonicalNumber(h.buf[:0], f)
+			n = len(h.buf)
+		}
+	}
+	if err := h.add(h.comma(false)+n, startIndex); err != nil {
+		return err
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
+
+// canonicalStringLen returns the length of the JCS form of the
+// valid string span, see appendCanonicalString.
+func canonicalStringLen(data []byte, startIndex, endIndex int) int {
+	n := 2
+	for i := startIndex + 1; i < endIndex-1; {
+		r, size := rune(data[i]), 1
+		if data[i] == '\\' {
+			r, size = decodeStringRune(data, i)
+		}
+		i += size
+		switch {
+		case r == '"' || r == '\\' || r == '\b' || r == '\t' ||
+			r == '\n' || r == '\f' || r == '\r':
+			n += 2
+		case r < ' ':
+			n += 6
+		case size == 1 || r < utf8.RuneSelf:
+			// raw bytes are kept as written
+			n++
+		case utf8.ValidRune(r):
+			n += utf8.RuneLen(r)
+		default:
+			// a lone surrogate decodes to utf8.RuneError
+			n += 3
+		}
+	}
+	return n
+}
+
+// canonicalizer writes the JCS form of a verified json.
+type canonicalizer struct {
+	data []byte
+	// s is used to find the end of the values.
+	s state
+}
+
+// member is an object member, key is the decoded key in
+// UTF-16 code units and value the offset of its value.
+type member struct {
+	key   []uint16
+	value int
+}
+
+// end returns the offset after the value at i.
+func (c *canonicalizer) end(i int) int {
+	outi, _, _ := validany(c.data, i, &c.s)
+	return outi
+}
+
+// appendValue appends the JCS form of the value at i to dst.
+func (c *canonicalizer) appendValue(dst []byte, i int) ([]byte, error) {
+	data := c.data
+	switch data[i] {
+	case '{':
+		var members []member
+		for i = skipSpace(data, i+1); data[i] != '}'; {
+			end := c.end(i)
+			key := utf16.Encode([]rune(string(
+				appendDecodedString(nil, data, i, end))))
+			i = skipSpace(data, end) + 1 // colon
+			i = skipSpace(data, i)
+			members = append(members, member{key: key, value: i})
+			i = skipSpace(data, c.end(i))
+			if data[i] == ',' {
+				i = skipSpace(data, i+1)
+			}
+		}
+		sort.SliceStable(members, func(a, b int) bool {
+			return lessUTF16(members[a].key, members[b].key)
+		})
+		dst = append(dst, '{')
+		for n, m := range members {
+			if n > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendCanonicalString(dst,
+				[]byte(string(utf16.Decode(m.key))))
+			dst = append(dst, ':')
+			var err error
+			if dst, err = c.appendValue(dst, m.value); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, '}'), nil
+	case '[':
+		dst = append(dst, '[')
+		for i = skipSpace(data, i+1); data[i] != ']'; {
+			if dst[len(dst)-1] != '[' {
+				dst = append(dst, ',')
+			}
+			var err error
+			if dst, err = c.appendValue(dst, i); err != nil {
+				return nil, err
+			}
+			i = skipSpace(data, c.end(i))
+			if data[i] == ',' {
+				i = skipSpace(data, i+1)
+			}
+		}
+		return append(dst, ']'), nil
+	case '"':
+		return appendCanonicalString(dst,
+			appendDecodedString(nil, data, i, c.end(i))), nil
+	case 't', 'f', 'n':
+		return append(dst, data[i:c.end(i)]...), nil
+	}
+	token := data[i:c.end(i)]
+	f, err := strconv.ParseFloat(string(token), 64)
+	if err != nil {
+		return nil, fmt.Errorf("jtp.numberOverflow.Token-[%s]", token)
+	}
+	return appendCanonicalNumber(dst, f), nil
+}
+
+// skipSpace returns the offset of the first non whitespace from i.
+func skipSpace(data []byte, i int) int {
+	for ; i < len(data); i++ {
+		if data[i] != ' ' && data[i] != '\t' &&
+			data[i] != '\n' && data[i] != '\r' {
+			break
+		}
+	}
+	return i
+}
+
+func lessUTF16(a, b []uint16) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// appendCanonicalString appends the decoded string s quoted with the
+// JCS minimal escaping.
+func appendCanonicalString(dst, s []byte) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	for _, c := range s {
+		switch c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		default:
+			if c < ' ' {
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+				continue
+			}
+			dst = append(dst, c)
+		}
+	}
+	return append(dst, '"')
+}
+
+// appendCanonicalNumber appends f formatted as the ECMAScript
+// Number.prototype.toString.
+func appendCanonicalNumber(dst []byte, f float64) []byte {
+	if f == 0 {
+		return append(dst, '0')
+	}
+	if f < 0 {
+		dst = append(dst, '-')
+		f = math.Abs(f)
+	}
+	// shortest digits d.ddde±x
+	e := strconv.AppendFloat(nil, f, 'e', -1, 64)
+	mantissa, exp := e, 0
+	for i, c := range e {
+		if c == 'e' {
+			mantissa = e[:i]
+			exp, _ = strconv.Atoi(string(e[i+1:]))
+			break
+		}
+	}
+	digits := make([]byte, 0, len(mantissa))
+	for _, c := range mantissa {
+		if c != '.' {
+			digits = append(digits, c)
+		}
+	}
+	// the value is 0.digits * 10^n
+	k, n := len(digits), exp+1
+	switch {
+	case k <= n && n <= 21:
+		dst = append(dst, digits...)
+		for ; k < n; k++ {
+			dst = append(dst, '0')
+		}
+	case 0 < n && n <= 21:
+		dst = append(dst, digits[:n]...)
+		dst = append(dst, '.')
+		dst = append(dst, digits[n:]...)
+	case -6 < n && n <= 0:
+		dst = append(dst, '0', '.')
+		for ; n < 0; n++ {
+			dst = append(dst, '0')
+		}
+		dst = append(dst, digits...)
+	default:
+		dst = append(dst, digits[0])
+		if k > 1 {
+			dst = append(dst, '.')
+			dst = append(dst, digits[1:]...)
+		}
+		dst = append(dst, 'e')
+		if n-1 >= 0 {
+			dst = append(dst, '+')
+		}
+		dst = strconv.AppendInt(dst, int64(n-1), 10)
+	}
+	return dst
+}
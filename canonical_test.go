@@ -0,0 +1,144 @@
+package gojtp
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestVerifyAndCanonicalize(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(4))
+	v := verifier.(Verify)
+	scenarios := []struct {
+		name string
+		json string
+		out  string
+		err  error
+		ok   bool
+	}{
+		{
+			// https://tools.ietf.org/html/rfc8785#section-3.2.2
+			name: "rfc 8785 example",
+			json: `{
+  "numbers": [333333333.33333329, 1E30, 4.50,
+              2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`,
+			out: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+			ok:  true,
+		},
+		{
+			// https://tools.ietf.org/html/rfc8785#section-3.2.3
+			name: "rfc 8785 sorting",
+			json: `{
+  "\u20ac": "Euro Sign",
+  "\r": "Carriage Return",
+  "\ufb33": "Hebrew Letter Dalet With Dagesh",
+  "1": "One",
+  "\ud83d\ude00": "Emoji: Grinning Face",
+  "\u0080": "Control",
+  "\u00f6": "Latin Small Letter O With Diaeresis"
+}`,
+			out: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\"," +
+				"\"ö\":\"Latin Small Letter O With Diaeresis\"," +
+				"\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\"," +
+				"\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+			ok: true,
+		},
+		{
+			name: "nested and empty",
+			json: ` [ {"b" : [ ], "a" : { } }, -0, 100, 1e21, 1e-7 ] `,
+			out:  `[{"a":{},"b":[]},0,100,1e+21,1e-7]`,
+			ok:   true,
+		},
+		{
+			name: "policy violation",
+			json: `[[[[[1]]]]]`,
+			err:  fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "overflow",
+			json: `[1e400]`,
+			err:  fmt.Errorf("jtp.numberOverflow.Token-[1e400]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			out, ok, err := v.VerifyAndCanonicalize([]byte(tc.json))
+			checkResult(t, tc.ok, ok, tc.err, err)
+			if string(out) != tc.out {
+				t.Errorf("Expected %s Got %s", tc.out, out)
+			}
+		})
+	}
+}
+
+func TestMaxCanonicalSize(t *testing.T) {
+	t.Parallel()
+	indented := "{\n" + strings.Repeat("    ", 100) + "\"a\":\n" +
+		strings.Repeat(" ", 1000) + "[ 1.50 ,\t\"\\u0041\" ]\n}"
+	compact := `{"a":"` + strings.Repeat("x", 100) + `"}`
+	verifier, _ := New(WithMaxCanonicalSize(100))
+	ok, err := verifier.VerifyString(indented)
+	checkResult(t, true, ok, nil, err)
+	ok, err = verifier.VerifyString(compact)
+	checkResult(t, false, ok, fmt.Errorf(
+		"jtp.maxCanonicalSizeReached.Max-[100]-Allowed.Found-[107]"), err)
+
+	// the size measured is the size of the JCS form
+	for _, json := range []string{
+		indented,
+		`{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 1e-27, -0],
+		  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/\ud83d\ude00\udead",
+		  "literals": [null, true, false, {}, []]}`,
+		` [ {"b" : [ ], "a" : { } }, -0, 100, 1e21, 1e-7 ] `,
+		`"top"`,
+		`12.50`,
+	} {
+		out, _, _ := Verify{}.VerifyAndCanonicalize([]byte(json))
+		verifier, _ := New(WithMaxCanonicalSize(len(out)))
+		ok, err := verifier.VerifyString(json)
+		checkResult(t, true, ok, nil, err)
+		verifier, _ = New(WithMaxCanonicalSize(len(out) - 1))
+		ok, err = verifier.VerifyString(json)
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxCanonicalSizeReached.Max-[%d]-Allowed.Found-[%d]",
+			len(out)-1, len(out)), err)
+	}
+}
+
+func TestAppendCanonicalNumber(t *testing.T) {
+	t.Parallel()
+	// https://tools.ietf.org/html/rfc8785#appendix-B
+	scenarios := []struct {
+		bits uint64
+		out  string
+	}{
+		{bits: 0x0000000000000000, out: "0"},
+		{bits: 0x8000000000000000, out: "0"},
+		{bits: 0x0000000000000001, out: "5e-324"},
+		{bits: 0x8000000000000001, out: "-5e-324"},
+		{bits: 0x7fefffffffffffff, out: "1.7976931348623157e+308"},
+		{bits: 0xffefffffffffffff, out: "-1.7976931348623157e+308"},
+		{bits: 0x4340000000000000, out: "9007199254740992"},
+		{bits: 0xc340000000000000, out: "-9007199254740992"},
+		{bits: 0x4430000000000000, out: "295147905179352830000"},
+		{bits: 0x44b52d02c7e14af5, out: "9.999999999999997e+22"},
+		{bits: 0x44b52d02c7e14af6, out: "1e+23"},
+		{bits: 0x3eb0c6f7a0b5ed8d, out: "0.000001"},
+		{bits: 0x3eb0c6f7a0b5ed8c, out: "9.999999999999997e-7"},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.out, func(t *testing.T) {
+			out := appendCanonicalNumber(nil, math.Float64frombits(tc.bits))
+			if string(out) != tc.out {
+				t.Errorf("Expected %s Got %s", tc.out, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package gojtp
+
+import "fmt"
+
+// WithMaxCharRunInString Option
+// Specifies the maximum number of identical consecutive characters
+// in a string value, once decoded, to reject the values padded with
+// a single character like "aaaa...", with
+// jtp.maxCharRunReached.Path-[/a/0].
+// zero value disable the checks
+func WithMaxCharRunInString(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max char run in string cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxCharRunInString = l
+		verifier.charRunEnabled = true
+		return nil
+	}
+}
+
+// charRunHandler checks the runs of identical characters of the
+// string values, forwarding the events to next if set.
+type charRunHandler struct {
+	pathForwarder
+	max int
+}
+
+// exceeded reports if the valid string span has a run of more than
+// max identical characters.
+func (h *charRunHandler) exceeded(data []byte, startIndex, endIndex int) bool {
+	var last rune
+	run := 0
+	for i := startIndex + 1; i < endIndex-1; {
+		r, size := decodeStringRune(data, i)
+		if run == 0 || r != last {
+			last, run = r, 0
+		}
+		if run++; run > h.max {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+func (h *charRunHandler) value(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.next()
+	if data[startIndex] == '"' && endIndex-startIndex-2 > h.max &&
+		h.exceeded(data, startIndex, endIndex) {
+		return fmt.Errorf("jtp.maxCharRunReached.Path-[%s]",
+			h.appendPointer(nil))
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
@@ -0,0 +1,51 @@
+package gojtp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMaxCharRunInString(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxCharRunInString(4))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "long run",
+			json: `{"a": ["ok", "x` + strings.Repeat("a", 30) + `"]}`,
+			err:  fmt.Errorf("jtp.maxCharRunReached.Path-[/a/1]"),
+			ok:   false,
+		},
+		{
+			name: "varied string of equal length",
+			json: `{"a": ["ok", "x` + strings.Repeat("abc", 10) + `"]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "escaped run",
+			json: `{"b": "aa\u0061\u0061a"}`,
+			err:  fmt.Errorf("jtp.maxCharRunReached.Path-[/b]"),
+			ok:   false,
+		},
+		{
+			name: "multibyte run at the limit",
+			json: `{"c": "ééééb", "dddddddd": 1}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ok, err := verifier.VerifyBytes([]byte(tc.json))
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
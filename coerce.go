@@ -0,0 +1,89 @@
+package gojtp
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// WithRejectCoercibleKeyCollisions Option
+// Rejects an object with two numeric property(key) names coercing to
+// the same JavaScript number, like {"1": x, "01": y} or "1" and "1.0",
+// which collide once the object is indexed by numbers. A key is
+// numeric if it is a number made of digits, signs, dots and exponents
+// once decoded.
+func WithRejectCoercibleKeyCollisions() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectCoercibleKeyCollisions = true
+		return nil
+	}
+}
+
+// coercedKey is a numeric key coerced to a number, within the object
+// counted by the walk.
+type coercedKey struct {
+	object int
+	value  float64
+}
+
+// coercionHandler checks the numeric keys of each object for
+// collisions, forwarding the events to next if set.
+type coercionHandler struct {
+	forwarder
+	// objects is the number of objects begun and open the open
+	// containers, the object number or -1 for an array.
+	objects int
+	open    []int
+	// seen maps the numeric keys to the span of their first key.
+	seen map[coercedKey][2]int
+	buf  []byte
+}
+
+// coerce returns the JavaScript number of the decoded key, if numeric.
+func coerce(key []byte) (float64, bool) {
+	for _, c := range key {
+		if !(c >= '0' && c <= '9' || c == '-' || c == '+' || c == '.' ||
+			c == 'e' || c == 'E') {
+			return 0, false
+		}
+	}
+	f, err := strconv.ParseFloat(string(key), 64)
+	if err != nil {
+		// out of range keys coerce to an infinity or zero
+		if ne, ok := err.(*strconv.NumError); !ok ||
+			ne.Err != strconv.ErrRange {
+			return 0, false
+		}
+	}
+	return f, true
+}
+
+func (h *coercionHandler) begin(c byte, i int) error {
+	object := -1
+	if c == '{' {
+		object = h.objects
+		h.objects++
+	}
+	h.open = append(h.open, object)
+	return h.forwarder.begin(c, i)
+}
+
+func (h *coercionHandler) end(c byte, i int) error {
+	h.open = h.open[:len(h.open)-1]
+	return h.forwarder.end(c, i)
+}
+
+func (h *coercionHandler) key(data []byte, startIndex, endIndex int) error {
+	h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+	if f, numeric := coerce(h.buf); numeric {
+		k := coercedKey{object: h.open[len(h.open)-1], value: f}
+		if first, found := h.seen[k]; found {
+			return fmt.Errorf("jtp.coercibleKeyCollision.Keys-[%s,%s]",
+				data[first[0]+1:first[1]-1], data[startIndex+1:endIndex-1])
+		}
+		if h.seen == nil {
+			h.seen = make(map[coercedKey][2]int)
+		}
+		h.seen[k] = [2]int{startIndex, endIndex}
+	}
+	return h.forwarder.key(data, startIndex, endIndex)
+}
@@ -0,0 +1,54 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRejectCoercibleKeyCollisions(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectCoercibleKeyCollisions())
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "distinct numeric keys",
+			json: `{"1": "x", "2": "y", "1a": {"1": 0}, "a": [{"1": 1}, {"1": 2}]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "leading zero",
+			json: `{"1": "x", "01": "y"}`,
+			err:  fmt.Errorf("jtp.coercibleKeyCollision.Keys-[1,01]"),
+			ok:   false,
+		},
+		{
+			name: "fraction and exponent",
+			json: `{"a": {"100": 1, "b": {}, "1e2": 2}}`,
+			err:  fmt.Errorf("jtp.coercibleKeyCollision.Keys-[100,1e2]"),
+			ok:   false,
+		},
+		{
+			name: "escaped key",
+			json: `{"\u0031": 1, "1.0": 2}`,
+			err:  fmt.Errorf(`jtp.coercibleKeyCollision.Keys-[\u0031,1.0]`),
+			ok:   false,
+		},
+		{
+			name: "signed zero",
+			json: `{"0": 1, "-0.0": 2}`,
+			err:  fmt.Errorf(`jtp.coercibleKeyCollision.Keys-[0,-0.0]`),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package gojtp
+
+// MaxCollectedStrings is the maximum number of strings
+// VerifyCollectStrings collects from a JSON.
+const MaxCollectedStrings = 1024
+
+// FoundString is a string value collected from a JSON.
+type FoundString struct {
+	// Path is the RFC 6901 JSON Pointer of the value.
+	Path string
+	// Value is the decoded string.
+	Value []byte
+}
+
+// collectHandler collects the string values matching pred.
+type collectHandler struct {
+	pathTracker
+	pred  func([]byte) bool
+	found []FoundString
+	buf   []byte
+}
+
+func (h *collectHandler) begin(c byte, i int) error {
+	h.pathTracker.begin(c)
+	return nil
+}
+
+func (h *collectHandler) end(c byte, i int) error {
+	h.pathTracker.end()
+	return nil
+}
+
+func (h *collectHandler) key(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.key(data, startIndex, endIndex)
+	return nil
+}
+
+func (h *collectHandler) value(data []byte, startIndex, endIndex int) error {
+	h.next()
+	if data[startIndex] != '"' {
+		return nil
+	}
+	h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+	if !h.pred(h.buf) {
+		return nil
+	}
+	if len(h.found) == MaxCollectedStrings {
+		return newLimitError(collectedStrings, MaxCollectedStrings,
+			MaxCollectedStrings+1, startIndex)
+	}
+	h.found = append(h.found, FoundString{
+		Path:  string(h.appendPointer(nil)),
+		Value: append([]byte(nil), h.buf...),
+	})
+	return nil
+}
+
+// VerifyCollectStrings is like VerifyBytes and also returns the string
+// values for which pred returns true, with their path, e.g. to scan
+// for personal data. pred receives the decoded string and must not
+// retain it.
+//
+// At most MaxCollectedStrings values are collected, beyond the
+// validation fails with maxCollectedStringsReached.
+func (v Verify) VerifyCollectStrings(json []byte,
+	pred func([]byte) bool) ([]FoundString, bool, error) {
+	h := &collectHandler{pred: pred}
+	s := state{verifier: v, h: h}
+	ok, err := s.verify(json)
+	return h.found, ok, err
+}
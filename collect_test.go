@@ -0,0 +1,43 @@
+package gojtp
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVerifyCollectStrings(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(7))
+	v := verifier.(Verify)
+	hasAt := func(b []byte) bool { return bytes.IndexByte(b, '@') >= 0 }
+
+	json := `{"users": [{"name": "a", "email": "a@example.com"},
+		{"name": "b", "contact": {"mails": ["b@example.com", "none"]}}],
+		"a/b~c": "x@y", "count": 2}`
+	found, ok, err := v.VerifyCollectStrings([]byte(json), hasAt)
+	checkResult(t, true, ok, nil, err)
+	expected := []FoundString{
+		{Path: "/users/0/email", Value: []byte("a@example.com")},
+		{Path: "/users/1/contact/mails/0", Value: []byte("b@example.com")},
+		{Path: "/a~1b~0c", Value: []byte("x@y")},
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %q Got %q", expected, found)
+	}
+
+	t.Run("collection bomb", func(t *testing.T) {
+		json := "[" + strings.TrimSuffix(strings.Repeat(`"@",`,
+			MaxCollectedStrings+1), ",") + "]"
+		found, ok, err := v.VerifyCollectStrings([]byte(json), hasAt)
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxCollectedStringsReached.Max-[%d]-Allowed.Found-[%d]",
+			MaxCollectedStrings, MaxCollectedStrings+1), err)
+		if len(found) != MaxCollectedStrings {
+			t.Errorf("Expected %d collected Got %d", MaxCollectedStrings,
+				len(found))
+		}
+	})
+}
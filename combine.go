@@ -0,0 +1,478 @@
+package gojtp
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+type combinedVerifier []Verifier
+
+// Combine returns a Verifier enforcing all the verifiers, e.g. an
+// organization wide policy and a stricter per endpoint one.
+// Its VerifyBytes runs each verifier in sequence and returns the
+// first failure, so the json is walked once per verifier; use Merge
+// to enforce several Verify in a single pass.
+func Combine(verifiers ...Verifier) Verifier {
+	return combinedVerifier(verifiers)
+}
+
+func (c combinedVerifier) VerifyBytes(json []byte) (bool, error) {
+	for _, v := range c {
+		if ok, err := v.VerifyBytes(json); !ok || err != nil {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+func (c combinedVerifier) VerifyString(json string) (bool, error) {
+	return c.VerifyBytes([]byte(json))
+}
+
+// VerifyReader reads the whole json from r, as each verifier walks
+// it, and verifies it with VerifyBytes.
+func (c combinedVerifier) VerifyReader(r io.Reader) (bool, error) {
+	json, err := ioutil.ReadAll(r)
+	if err != nil {
+		return false, err
+	}
+	return c.VerifyBytes(json)
+}
+
+// Merge returns a Verify enforcing the stricter of each limit of the
+// verifiers in one pass: the smallest of the enabled limits, any
+// rejection, the characters allowed by every charset, all the
+// number and string validators in order, the URL keys, string
+// formats, required top level keys, discriminators, sorted arrays and
+// uniform object values of any verifier and the keys exempt from the depth or string length
+// limit in all the verifiers.
+// Only the documents allowlisted by all the verifiers are allowed.
+// The warnings and the type schemas are united, the first verifier wins for a pointer
+// in several schemas.
+// The array unit budget takes the smallest budget and the largest
+// object weight, the minimum average object fill the largest minimum, the string
+// value entropy the smallest entropy and length, the decoded memory costs the largest of each cost,
+// the depth limits and the array and object limit overrides the smallest
+// effective limit of each level or key,
+// and the first approach warning and versioned limits set are kept.
+func Merge(verifiers ...Verify) Verify {
+	if len(verifiers) == 0 {
+		return Verify{}
+	}
+	m := verifiers[0]
+	for _, v := range verifiers[1:] {
+		depthLimits := mergeDepthLimits(&m, &v)
+		arrayOverrides := mergeKeyLimits(
+			m.ArrayLimitOverrides, m.MaxArrayElementCount,
+			m.arrayEntryCountEnabled, v.ArrayLimitOverrides,
+			v.MaxArrayElementCount, v.arrayEntryCountEnabled)
+		objectOverrides := mergeKeyLimits(
+			m.ObjectEntryLimitOverrides, m.ObjectEntryCount,
+			m.objectEntryCountEnabled, v.ObjectEntryLimitOverrides,
+			v.ObjectEntryCount, v.objectEntryCountEnabled)
+		stricter(&m.MaxArrayElementCount, &m.arrayEntryCountEnabled,
+			v.MaxArrayElementCount, v.arrayEntryCountEnabled)
+		if v.arrayUnitBudgetEnabled {
+			weight := maxOf(m.ArrayObjectWeight, v.ArrayObjectWeight)
+			stricter(&m.ArrayUnitBudget, &m.arrayUnitBudgetEnabled,
+				v.ArrayUnitBudget, v.arrayUnitBudgetEnabled)
+			m.ArrayObjectWeight = weight
+		}
+		stricter(&m.MaxExponentDigits, &m.exponentDigitsEnabled,
+			v.MaxExponentDigits, v.exponentDigitsEnabled)
+		stricter(&m.MaxNumberDigits, &m.numberDigitsEnabled,
+			v.MaxNumberDigits, v.numberDigitsEnabled)
+		stricter(&m.MaxBareDigits, &m.bareDigitsEnabled,
+			v.MaxBareDigits, v.bareDigitsEnabled)
+		stricter(&m.MaxFloatCount, &m.floatCountEnabled,
+			v.MaxFloatCount, v.floatCountEnabled)
+		stricter(&m.MaxTotalNumberBytes, &m.totalNumberBytesEnabled,
+			v.MaxTotalNumberBytes, v.totalNumberBytesEnabled)
+		stricter(&m.MaxArrayLiteralBytes, &m.arrayLiteralBytesEnabled,
+			v.MaxArrayLiteralBytes, v.arrayLiteralBytesEnabled)
+		stricter(&m.MaxLeadingScalars, &m.leadingScalarsEnabled,
+			v.MaxLeadingScalars, v.leadingScalarsEnabled)
+		stricter(&m.MaxArrayRank, &m.arrayRankEnabled,
+			v.MaxArrayRank, v.arrayRankEnabled)
+		stricter(&m.MaxFlattenedArrayElements, &m.flattenedElementsEnabled,
+			v.MaxFlattenedArrayElements, v.flattenedElementsEnabled)
+		stricter(&m.FlattenedLeaves, &m.flattenedLeavesEnabled,
+			v.FlattenedLeaves, v.flattenedLeavesEnabled)
+		stricter(&m.MaxTableCells, &m.tableCellsEnabled,
+			v.MaxTableCells, v.tableCellsEnabled)
+		stricter(&m.JSONContainerDepth, &m.jsonContainerDepthEnabled,
+			v.JSONContainerDepth, v.jsonContainerDepthEnabled)
+		stricter(&m.ObjectEntryCount, &m.objectEntryCountEnabled,
+			v.ObjectEntryCount, v.objectEntryCountEnabled)
+		stricter(&m.MaxScanBudget, &m.scanBudgetEnabled,
+			v.MaxScanBudget, v.scanBudgetEnabled)
+		stricter(&m.MaxTokenGap, &m.tokenGapEnabled,
+			v.MaxTokenGap, v.tokenGapEnabled)
+		stricter(&m.MaxTokenBytes, &m.tokenBytesEnabled,
+			v.MaxTokenBytes, v.tokenBytesEnabled)
+		stricter(&m.MaxCanonicalSize, &m.canonicalSizeEnabled,
+			v.MaxCanonicalSize, v.canonicalSizeEnabled)
+		stricter(&m.MaxEmbeddedJSONDepth, &m.embeddedJSONEnabled,
+			v.MaxEmbeddedJSONDepth, v.embeddedJSONEnabled)
+		m.FlagEmbeddedJSON = m.FlagEmbeddedJSON || v.FlagEmbeddedJSON
+		stricter(&m.MaxEmptyValues, &m.emptyValuesEnabled,
+			v.MaxEmptyValues, v.emptyValuesEnabled)
+		stricter(&m.CumulativeMaxEntries, &m.cumulativeEntriesEnabled,
+			v.CumulativeMaxEntries, v.cumulativeEntriesEnabled)
+		stricter(&m.CumulativeMaxBytes, &m.cumulativeBytesEnabled,
+			v.CumulativeMaxBytes, v.cumulativeBytesEnabled)
+		stricter(&m.StreamMaxDistinctTopLevelKeys,
+			&m.streamTopLevelKeysEnabled,
+			v.StreamMaxDistinctTopLevelKeys, v.streamTopLevelKeysEnabled)
+		stricter(&m.MaxSubtreeEntries, &m.subtreeEntriesEnabled,
+			v.MaxSubtreeEntries, v.subtreeEntriesEnabled)
+		stricter(&m.ObjectKeyLength, &m.objectKeyLengthEnabled,
+			v.ObjectKeyLength, v.objectKeyLengthEnabled)
+		stricter(&m.ObjectKeyByteLength, &m.keyByteLengthEnabled,
+			v.ObjectKeyByteLength, v.keyByteLengthEnabled)
+		stricter(&m.MaxTotalKeyBytes, &m.totalKeyBytesEnabled,
+			v.MaxTotalKeyBytes, v.totalKeyBytesEnabled)
+		stricter(&m.TopLevelKeyLength, &m.topLevelKeyLengthEnabled,
+			v.TopLevelKeyLength, v.topLevelKeyLengthEnabled)
+		stricter(&m.StringValueLen, &m.stringLenEnabled,
+			v.StringValueLen, v.stringLenEnabled)
+		stricter(&m.StringValueWeight, &m.stringWeightEnabled,
+			v.StringValueWeight, v.stringWeightEnabled)
+		stricter(&m.MaxTotalEscapes, &m.totalEscapesEnabled,
+			v.MaxTotalEscapes, v.totalEscapesEnabled)
+		stricter(&m.MaxConsecutiveEscapes, &m.consecutiveEscapesEnabled,
+			v.MaxConsecutiveEscapes, v.consecutiveEscapesEnabled)
+		stricter(&m.MaxScriptsPerString, &m.scriptsEnabled,
+			v.MaxScriptsPerString, v.scriptsEnabled)
+		stricter(&m.MaxCombiningMarks, &m.combiningMarksEnabled,
+			v.MaxCombiningMarks, v.combiningMarksEnabled)
+		if v.identicalRepeatsEnabled {
+			length := maxOf(m.TrackedValueLength, v.TrackedValueLength)
+			stricter(&m.MaxIdenticalValueRepeats, &m.identicalRepeatsEnabled,
+				v.MaxIdenticalValueRepeats, v.identicalRepeatsEnabled)
+			m.TrackedValueLength = length
+		}
+		if v.valueFractionEnabled {
+			length := maxOf(m.TrackedValueLength, v.TrackedValueLength)
+			if !m.valueFractionEnabled || v.MaxValueRepetitionFraction <
+				m.MaxValueRepetitionFraction {
+				m.MaxValueRepetitionFraction = v.MaxValueRepetitionFraction
+			}
+			m.valueFractionEnabled = true
+			m.TrackedValueLength = length
+		}
+		if v.keyRepeatsEnabled {
+			length := maxOf(m.TrackedKeyLength, v.TrackedKeyLength)
+			stricter(&m.MaxGlobalKeyRepeats, &m.keyRepeatsEnabled,
+				v.MaxGlobalKeyRepeats, v.keyRepeatsEnabled)
+			m.TrackedKeyLength = length
+		}
+		if v.entropyEnabled && (!m.entropyEnabled ||
+			v.MaxStringValueEntropy < m.MaxStringValueEntropy) {
+			m.MaxStringValueEntropy = v.MaxStringValueEntropy
+		}
+		if v.entropyEnabled && (!m.entropyEnabled ||
+			v.MinEntropyStringLength < m.MinEntropyStringLength) {
+			m.MinEntropyStringLength = v.MinEntropyStringLength
+		}
+		m.entropyEnabled = m.entropyEnabled || v.entropyEnabled
+		stricter(&m.MaxCharRunInString, &m.charRunEnabled,
+			v.MaxCharRunInString, v.charRunEnabled)
+		stricter(&m.MaxContainerCount, &m.containerCountEnabled,
+			v.MaxContainerCount, v.containerCountEnabled)
+		stricter(&m.MaxTotalObjects, &m.totalObjectsEnabled,
+			v.MaxTotalObjects, v.totalObjectsEnabled)
+		if v.keyValueRatioEnabled && (!m.keyValueRatioEnabled ||
+			v.MaxKeyValueLengthRatio < m.MaxKeyValueLengthRatio) {
+			m.MaxKeyValueLengthRatio = v.MaxKeyValueLengthRatio
+			m.keyValueRatioEnabled = true
+		}
+		if v.objectFillEnabled && (!m.objectFillEnabled ||
+			v.MinAverageObjectFill > m.MinAverageObjectFill) {
+			m.MinAverageObjectFill = v.MinAverageObjectFill
+			m.objectFillEnabled = true
+		}
+		stricter(&m.MaxColonWhitespace, &m.colonWhitespaceEnabled,
+			v.MaxColonWhitespace, v.colonWhitespaceEnabled)
+		stricter(&m.MaxDocumentSize, &m.documentSizeEnabled,
+			v.MaxDocumentSize, v.documentSizeEnabled)
+		stricter(&m.MaxStructuralStates, &m.structuralStatesEnabled,
+			v.MaxStructuralStates, v.structuralStatesEnabled)
+		stricter(&m.MaxStructureTransitions, &m.transitionsEnabled,
+			v.MaxStructureTransitions, v.transitionsEnabled)
+		stricter(&m.MaxConsecutiveOpens, &m.consecutiveOpensEnabled,
+			v.MaxConsecutiveOpens, v.consecutiveOpensEnabled)
+		stricter(&m.MaxBytesPerChild, &m.bytesPerChildEnabled,
+			v.MaxBytesPerChild, v.bytesPerChildEnabled)
+		stricter(&m.StringValueUTF16Units, &m.stringUTF16UnitsEnabled,
+			v.StringValueUTF16Units, v.stringUTF16UnitsEnabled)
+		stricter(&m.ObjectKeyUTF16Units, &m.keyUTF16UnitsEnabled,
+			v.ObjectKeyUTF16Units, v.keyUTF16UnitsEnabled)
+
+		if v.decodedMemoryEnabled {
+			costs := m.DecodedMemoryCosts
+			stricter(&m.MaxDecodedMemoryEstimate, &m.decodedMemoryEnabled,
+				v.MaxDecodedMemoryEstimate, v.decodedMemoryEnabled)
+			m.DecodedMemoryCosts = MemoryCosts{
+				ObjectEntry: maxOf(costs.ObjectEntry,
+					v.DecodedMemoryCosts.ObjectEntry),
+				ArrayElement: maxOf(costs.ArrayElement,
+					v.DecodedMemoryCosts.ArrayElement),
+			}
+		}
+
+		m.RejectReplacementChar = m.RejectReplacementChar ||
+			v.RejectReplacementChar
+		m.RejectNullCharInStrings = m.RejectNullCharInStrings ||
+			v.RejectNullCharInStrings
+		m.RejectNoncharacters = m.RejectNoncharacters ||
+			v.RejectNoncharacters
+		m.RejectZeroWidthChars = m.RejectZeroWidthChars ||
+			v.RejectZeroWidthChars
+		m.RejectBidiControls = m.RejectBidiControls || v.RejectBidiControls
+		m.RejectExtendedControls = m.RejectExtendedControls ||
+			v.RejectExtendedControls
+		m.ForwardSlashPolicy |= v.ForwardSlashPolicy
+		m.KeysMustBeIdentifiers = m.KeysMustBeIdentifiers ||
+			v.KeysMustBeIdentifiers
+		m.RejectNumericKeys = m.RejectNumericKeys || v.RejectNumericKeys
+		m.RejectCoercibleKeyCollisions = m.RejectCoercibleKeyCollisions ||
+			v.RejectCoercibleKeyCollisions
+		m.RequireStructuredTopLevel = m.RequireStructuredTopLevel ||
+			v.RequireStructuredTopLevel
+		m.QuickReject = m.QuickReject || v.QuickReject
+		m.TopLevelMustBeNonEmpty = m.TopLevelMustBeNonEmpty ||
+			v.TopLevelMustBeNonEmpty
+		m.RejectLossyNumbers = m.RejectLossyNumbers || v.RejectLossyNumbers
+		m.Int64RangeNumbers = m.Int64RangeNumbers || v.Int64RangeNumbers
+		switch {
+		case !m.HomogeneousArrays:
+			m.NullsInHomogeneousArrays = v.NullsInHomogeneousArrays
+		case v.HomogeneousArrays:
+			m.NullsInHomogeneousArrays = m.NullsInHomogeneousArrays &&
+				v.NullsInHomogeneousArrays
+		}
+		m.HomogeneousArrays = m.HomogeneousArrays || v.HomogeneousArrays
+		m.UniformObjectKeysInArrays = m.UniformObjectKeysInArrays ||
+			v.UniformObjectKeysInArrays
+		m.DisallowNull = m.DisallowNull || v.DisallowNull
+		stricter(&m.MaxTrueCount, &m.trueCountEnabled,
+			v.MaxTrueCount, v.trueCountEnabled)
+		m.RejectUnderflowNumbers = m.RejectUnderflowNumbers ||
+			v.RejectUnderflowNumbers
+		m.RejectOverflowNumbers = m.RejectOverflowNumbers ||
+			v.RejectOverflowNumbers
+		m.RejectNegativeZero = m.RejectNegativeZero || v.RejectNegativeZero
+		m.ASCIIOnly = m.ASCIIOnly || v.ASCIIOnly
+		m.PlainDecimalNumbersOnly = m.PlainDecimalNumbersOnly ||
+			v.PlainDecimalNumbersOnly
+		m.CanonicalNumbers = m.CanonicalNumbers || v.CanonicalNumbers
+		m.TolerateRawControlInStrings = m.TolerateRawControlInStrings &&
+			v.TolerateRawControlInStrings
+		m.SkipStringValidation = m.SkipStringValidation &&
+			v.SkipStringValidation
+		m.SkipNumberValidation = m.SkipNumberValidation &&
+			v.SkipNumberValidation
+
+		if m.versionedLimits == nil {
+			m.versionKey, m.versionedLimits = v.versionKey,
+				v.versionedLimits
+		}
+		if m.approachFn == nil {
+			m.approachFraction, m.approachFn = v.approachFraction,
+				v.approachFn
+		}
+		if a, b := m.stringValueCharset, v.stringValueCharset; a == nil {
+			m.stringValueCharset = b
+		} else if b != nil {
+			m.stringValueCharset = func(r rune) bool {
+				return a(r) && b(r)
+			}
+		}
+		if a, b := m.numberValidator, v.numberValidator; a == nil {
+			m.numberValidator = b
+		} else if b != nil {
+			m.numberValidator = func(token []byte) error {
+				if err := a(token); err != nil {
+					return err
+				}
+				return b(token)
+			}
+		}
+		if a, b := m.stringValidator, v.stringValidator; a == nil {
+			m.stringValidator = b
+		} else if b != nil {
+			m.stringValidator = func(value []byte, isKey bool) error {
+				if err := a(value, isKey); err != nil {
+					return err
+				}
+				return b(value, isKey)
+			}
+		}
+		if len(v.typeSchema) > 0 {
+			schema := make(map[string]Type,
+				len(m.typeSchema)+len(v.typeSchema))
+			for pointer, t := range v.typeSchema {
+				schema[pointer] = t
+			}
+			for pointer, t := range m.typeSchema {
+				schema[pointer] = t
+			}
+			m.typeSchema = schema
+		}
+		m.warnings = append(m.warnings[:len(m.warnings):len(m.warnings)],
+			v.warnings...)
+		m.DepthLimits = depthLimits
+		m.ArrayLimitOverrides = arrayOverrides
+		m.ObjectEntryLimitOverrides = objectOverrides
+		m.URLKeys = append(m.URLKeys[:len(m.URLKeys):len(m.URLKeys)],
+			v.URLKeys...)
+		m.RequireHTTPSURLs = m.RequireHTTPSURLs || v.RequireHTTPSURLs
+		m.stringFormats = append(
+			m.stringFormats[:len(m.stringFormats):len(m.stringFormats)],
+			v.stringFormats...)
+		m.epochRanges = append(
+			m.epochRanges[:len(m.epochRanges):len(m.epochRanges)],
+			v.epochRanges...)
+		m.allowlist = intersectAllowlists(m.allowlist, v.allowlist)
+		m.discriminators = append(
+			m.discriminators[:len(m.discriminators):len(m.discriminators)],
+			v.discriminators...)
+		m.uniformValues = append(
+			m.uniformValues[:len(m.uniformValues):len(m.uniformValues)],
+			v.uniformValues...)
+		m.sortedArrays = append(
+			m.sortedArrays[:len(m.sortedArrays):len(m.sortedArrays)],
+			v.sortedArrays...)
+		m.RequiredTopLevelKeys = union(m.RequiredTopLevelKeys,
+			v.RequiredTopLevelKeys)
+		if m.allowedTopLevelKeysEnabled && v.allowedTopLevelKeysEnabled {
+			m.AllowedTopLevelKeys = intersect(m.AllowedTopLevelKeys,
+				v.AllowedTopLevelKeys)
+		} else if v.allowedTopLevelKeysEnabled {
+			m.AllowedTopLevelKeys = v.AllowedTopLevelKeys
+		}
+		m.allowedTopLevelKeysEnabled = m.allowedTopLevelKeysEnabled ||
+			v.allowedTopLevelKeysEnabled
+		m.DepthExemptKeys = intersect(m.DepthExemptKeys, v.DepthExemptKeys)
+		m.RelaxedStringLengthKeys = intersect(m.RelaxedStringLengthKeys,
+			v.RelaxedStringLengthKeys)
+	}
+	return m
+}
+
+// mergeDepthLimits returns the smallest of the limits of a and b at
+// each depth level with an entry in either, before their global
+// limits are merged.
+func mergeDepthLimits(a, b *Verify) map[int]Limits {
+	if len(a.DepthLimits) == 0 && len(b.DepthLimits) == 0 {
+		return nil
+	}
+	depthLimits := make(map[int]Limits)
+	for _, v := range []*Verify{a, b} {
+		for depth := range v.DepthLimits {
+			var l Limits
+			var enabled bool
+			l.ObjectKeyLength, enabled = a.keyLengthLimit(depth)
+			key, keyEnabled := b.keyLengthLimit(depth)
+			stricter(&l.ObjectKeyLength, &enabled, key, keyEnabled)
+			l.StringValueLen, enabled = a.stringLengthLimit(depth)
+			str, strEnabled := b.stringLengthLimit(depth)
+			stricter(&l.StringValueLen, &enabled, str, strEnabled)
+			depthLimits[depth] = l
+		}
+	}
+	return depthLimits
+}
+
+// mergeKeyLimits returns the smallest of the limit overrides a and b,
+// each falling back to its global limit, for each key overridden
+// in either.
+func mergeKeyLimits(a map[string]int, aMax int, aEnabled bool,
+	b map[string]int, bMax int, bEnabled bool) map[string]int {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	limit := func(overrides map[string]int, max int, enabled bool,
+		key string) (int, bool) {
+		if l, ok := overrides[key]; ok {
+			return l, l > 0
+		}
+		return max, enabled
+	}
+	merged := make(map[string]int)
+	for _, overrides := range []map[string]int{a, b} {
+		for key := range overrides {
+			l, enabled := limit(a, aMax, aEnabled, key)
+			bl, bEnabled := limit(b, bMax, bEnabled, key)
+			stricter(&l, &enabled, bl, bEnabled)
+			if !enabled {
+				l = 0
+			}
+			merged[key] = l
+		}
+	}
+	return merged
+}
+
+// intersect returns the strings both in a and b.
+func intersect(a, b []string) []string {
+	var both []string
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				both = append(both, x)
+				break
+			}
+		}
+	}
+	return both
+}
+
+// intersectAllowlists returns the documents allowed by both a and b.
+func intersectAllowlists(a, b map[string]struct{}) map[string]struct{} {
+	if a == nil || b == nil {
+		return nil
+	}
+	both := make(map[string]struct{})
+	for doc := range a {
+		if _, ok := b[doc]; ok {
+			both[doc] = struct{}{}
+		}
+	}
+	return both
+}
+
+// union returns the strings of a followed by the ones of b not in a.
+func union(a, b []string) []string {
+	all := a[:len(a):len(a)]
+	for _, y := range b {
+		if !stringsContain(all, y) {
+			all = append(all, y)
+		}
+	}
+	return all
+}
+
+func stringsContain(a []string, x string) bool {
+	for _, y := range a {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
+
+// stricter sets the limit to l if enabled and smaller.
+func stricter(limit *int, enabled *bool, l int, e bool) {
+	if e && (!*enabled || l < *limit) {
+		*limit, *enabled = l, true
+	}
+}
+
+func maxOf(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,117 @@
+package gojtp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCombine(t *testing.T) {
+	t.Parallel()
+	base, _ := New(WithMaxContainerDepth(4), WithMaxStringLength(10))
+	endpoint, _ := New(WithMaxArrayElementCount(2), WithMaxStringLength(5))
+	combined := Combine(base, endpoint)
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{name: "both pass", json: `[["abc"], {"a": 1}]`, err: nil, ok: true},
+		{
+			name: "base fails",
+			json: `[[[[[1]]]]]`,
+			err:  fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "endpoint fails",
+			json: `["abcdef"]`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+		{
+			name: "first failure",
+			json: `["abcdefghijk", 1, 2]`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[10]-Allowed.Found-[11]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := combined.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	base, _ := New(WithMaxContainerDepth(4), WithMaxStringLength(10),
+		WithDepthExemptKey("a"), WithDepthExemptKey("b"),
+		WithStringValueCharset(func(r rune) bool { return r != 'x' }))
+	endpoint, _ := New(WithMaxContainerDepth(6), WithMaxStringLength(5),
+		WithMaxArrayElementCount(2), WithRejectLossyNumbers(),
+		WithDepthExemptKey("b"),
+		WithStringValueCharset(func(r rune) bool { return r != 'y' }))
+	merged := Merge(base.(Verify), endpoint.(Verify))
+	if merged.JSONContainerDepth != 4 || merged.StringValueLen != 5 ||
+		merged.MaxArrayElementCount != 2 || !merged.RejectLossyNumbers {
+		t.Errorf("Expected the stricter limits Got %+v", merged)
+	}
+	if len(merged.DepthExemptKeys) != 1 || merged.DepthExemptKeys[0] != "b" {
+		t.Errorf("Expected exempt keys [b] Got %v", merged.DepthExemptKeys)
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{name: "passes", json: `[["abc"], {"a": 1}]`, err: nil, ok: true},
+		{
+			name: "depth",
+			json: `{"a": [[[[1]]]]}`,
+			err:  fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "string length",
+			json: `["abcdef"]`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+		{
+			name: "element count",
+			json: `[1, 2, 3]`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "charset",
+			json: `["ay"]`,
+			err:  fmt.Errorf("jtp.disallowedStringCharacter.Char-[y].Offset-[3]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := merged.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMergeDepthLimits(t *testing.T) {
+	t.Parallel()
+	base, _ := New(WithMaxStringLength(4),
+		WithDepthLimits(map[int]Limits{2: {StringValueLen: 10}}))
+	endpoint, _ := New(WithMaxStringLength(8),
+		WithDepthLimits(map[int]Limits{1: {StringValueLen: 6}}))
+	merged := Merge(base.(Verify), endpoint.(Verify))
+	expected := map[int]Limits{1: {StringValueLen: 4}, 2: {StringValueLen: 8}}
+	if !reflect.DeepEqual(merged.DepthLimits, expected) {
+		t.Errorf("Expected depth limits %v Got %v", expected,
+			merged.DepthLimits)
+	}
+}
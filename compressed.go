@@ -0,0 +1,41 @@
+package gojtp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+)
+
+// VerifyCompressed returns true if the data, compressed with the
+// HTTP content encoding enc, is valid json and is JSON THREAT
+// Protection Safe.
+// Supported encodings are gzip, deflate (zlib format) and identity.
+//
+// The decompression stops as soon as the decompressed size exceeds
+// the MaxDocumentSize, so decompression bombs are rejected with
+// maxDocumentSizeReached without being inflated, in this case the
+// reported Found is a lower bound of the decompressed size.
+// Without WithMaxDocumentSize the whole data is decompressed in memory.
+func (v Verify) VerifyCompressed(data []byte, enc string) (bool, error) {
+	var (
+		r   io.Reader
+		err error
+	)
+	switch enc {
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(bytes.NewReader(data))
+	case "deflate":
+		r, err = zlib.NewReader(bytes.NewReader(data))
+	case "", "identity":
+		return v.VerifyBytes(data)
+	default:
+		return false, fmt.Errorf("jtp: unsupported content encoding %q",
+			enc)
+	}
+	if err != nil {
+		return false, err
+	}
+	return v.verifyReader(r)
+}
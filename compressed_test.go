@@ -0,0 +1,70 @@
+package gojtp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"testing"
+)
+
+func TestVerifyCompressed(t *testing.T) {
+	t.Parallel()
+	var gz, zl bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write(_getTestJSONBytes())
+	_ = w.Close()
+	zw := zlib.NewWriter(&zl)
+	_, _ = zw.Write(_getTestJSONBytes())
+	_ = zw.Close()
+
+	var bomb bytes.Buffer
+	w = gzip.NewWriter(&bomb)
+	_, _ = w.Write([]byte("["))
+	for i := 0; i < 64; i++ {
+		_, _ = w.Write(bytes.Repeat([]byte(" "), 1<<20))
+	}
+	_, _ = w.Write([]byte("]"))
+	_ = w.Close()
+
+	verifier, _ := New(WithMaxDocumentSize(4096),
+		WithMaxContainerDepth(7))
+	v := verifier.(Verify)
+	scenarios := []struct {
+		name string
+		data []byte
+		enc  string
+		err  error
+		ok   bool
+	}{
+		{name: "gzip", data: gz.Bytes(), enc: "gzip", err: nil, ok: true},
+		{name: "deflate", data: zl.Bytes(), enc: "deflate", err: nil, ok: true},
+		{
+			name: "identity",
+			data: _getTestJSONBytes(),
+			enc:  "identity",
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "decompression bomb",
+			data: bomb.Bytes(),
+			enc:  "gzip",
+			err:  fmt.Errorf("jtp.maxDocumentSizeReached.Max-[4096]-Allowed.Found-[4097]"),
+			ok:   false,
+		},
+		{
+			name: "unsupported",
+			data: gz.Bytes(),
+			enc:  "br",
+			err:  fmt.Errorf(`jtp: unsupported content encoding "br"`),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := v.VerifyCompressed(tc.data, tc.enc)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
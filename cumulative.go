@@ -0,0 +1,87 @@
+package gojtp
+
+import "fmt"
+
+// StreamValidator verifies a stream of JSON documents, like a batch,
+// against the limits of a Verify and the cumulative limits, set with
+// WithCumulativeMaxEntries, WithCumulativeMaxBytes and
+// WithStreamMaxDistinctTopLevelKeys, which apply to all the
+// documents fed combined.
+// A StreamValidator is not safe for concurrent use.
+type StreamValidator struct {
+	verifier Verify
+	entries  int
+	bytes    int
+	// keys has the distinct top level keys fed so far.
+	keys map[string]struct{}
+}
+
+// NewStreamValidator returns a StreamValidator with the limits of v
+// and empty cumulative counters.
+func (v Verify) NewStreamValidator() *StreamValidator {
+	return &StreamValidator{verifier: v}
+}
+
+// Feed returns true if the next json of the stream is valid json,
+// and is JSON THREAT Protection Safe, including the cumulative limits
+// with the documents fed before.
+// Only the documents which pass are added to the cumulative counters.
+func (sv *StreamValidator) Feed(json []byte) (bool, error) {
+	s := state{verifier: sv.verifier, entries: sv.entries,
+		streamBytes: sv.bytes, streamKeys: sv.keys}
+	ok, err := s.verify(json)
+	if ok && err == nil {
+		sv.entries = s.entries
+		sv.bytes += len(json)
+		if len(s.newKeys) > 0 && sv.keys == nil {
+			sv.keys = make(map[string]struct{}, len(s.newKeys))
+		}
+		for _, key := range s.newKeys {
+			sv.keys[key] = struct{}{}
+		}
+	}
+	return ok, err
+}
+
+// WithStreamMaxDistinctTopLevelKeys Option
+// Specifies the maximum number of distinct top level keys, once
+// decoded, allowed in all the JSON fed to a StreamValidator combined,
+// to bound the schema drift over a stream, for VerifyBytes the stream
+// is the single JSON.
+// zero value disable the checks
+func WithStreamMaxDistinctTopLevelKeys(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: stream max distinct top level keys"+
+				" cannot be negative %d", l)
+		}
+		verifier.StreamMaxDistinctTopLevelKeys = l
+		verifier.streamTopLevelKeysEnabled = true
+		return nil
+	}
+}
+
+// addStreamKey adds the top level key span to the distinct keys of
+// the stream if it was not seen before.
+func (s *state) addStreamKey(data []byte, start, end int) error {
+	key := string(appendDecodedString(nil, data, start, end))
+	if _, ok := s.streamKeys[key]; ok {
+		return nil
+	}
+	// newKeys is bounded by the limit, a scan is enough
+	for _, k := range s.newKeys {
+		if k == key {
+			return nil
+		}
+	}
+	s.newKeys = append(s.newKeys, key)
+	n := len(s.streamKeys) + len(s.newKeys)
+	if n > s.verifier.StreamMaxDistinctTopLevelKeys {
+		return newLimitError(streamTopLevelKeys,
+			s.verifier.StreamMaxDistinctTopLevelKeys, n, start)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStreamValidator(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithCumulativeMaxEntries(5),
+		WithCumulativeMaxBytes(64), WithMaxObjectEntryCount(3))
+	v := verifier.(Verify)
+	type fed struct {
+		json string
+		err  error
+	}
+	entriesErr := fmt.Errorf("jtp.maxCumulativeEntriesReached.Max-[5]-Allowed.Found-[6]")
+	bytesErr := fmt.Errorf("jtp.maxCumulativeBytesReached.Max-[64]-Allowed.Found-[81]")
+	countErr := fmt.Errorf("jtp.maxObjectEntryCountReached.Max-[3]-Allowed.Found-[4]")
+	scenarios := []struct {
+		name string
+		feed []fed
+	}{
+		{
+			name: "within",
+			feed: []fed{
+				{json: `{"a": 1}`},
+				{json: `[{"b": 2, "c": 3}]`},
+				{json: `{"d": {"e": 5}}`},
+			},
+		},
+		{
+			name: "entries cumulatively exceeded",
+			feed: []fed{
+				{json: `{"a": 1, "b": 2}`},
+				{json: `{"c": 3, "d": 4}`},
+				{json: `{"e": 5, "f": 6}`, err: entriesErr},
+			},
+		},
+		{
+			name: "bytes cumulatively exceeded",
+			feed: []fed{
+				{json: `[1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+				{json: `[1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+				{json: `[1, 2, 3, 4, 5, 6, 7, 8, 9]`, err: bytesErr},
+			},
+		},
+		{
+			name: "failed document is not counted",
+			feed: []fed{
+				{json: `{"a": 1, "b": 2, "c": 3, "d": 4}`, err: countErr},
+				{json: `{"a": 1, "b": 2, "c": 3}`},
+				{json: `{"d": 4, "e": 5}`},
+			},
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := v.NewStreamValidator()
+			for _, f := range tc.feed {
+				ok, err := sv.Feed([]byte(f.json))
+				checkResult(t, f.err == nil, ok, f.err, err)
+			}
+		})
+	}
+}
+
+func TestStreamMaxDistinctTopLevelKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithStreamMaxDistinctTopLevelKeys(3))
+	v := verifier.(Verify)
+	keysErr := fmt.Errorf("jtp.streamTooManyDistinctTopLevelKeys.Max-[3]-Allowed.Found-[4]")
+	sv := v.NewStreamValidator()
+	feed := []struct {
+		json string
+		err  error
+	}{
+		{json: `{"a": 1, "b": {"x": 1, "y": 2}}`},
+		{json: `{"a": 2, "b": {"z": 3}}`},
+		{json: `[{"c": 1, "d": 2}]`},
+		{json: `{"a": 3, "c": 4}`},
+		{json: `{"b": 1, "d": 2, "e": 3}`, err: keysErr},
+		{json: `{"a": 1, "b": 2, "c": 3}`},
+		{json: `{"d": 1}`, err: keysErr},
+	}
+	for _, f := range feed {
+		ok, err := sv.Feed([]byte(f.json))
+		checkResult(t, f.err == nil, ok, f.err, err)
+	}
+}
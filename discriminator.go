@@ -0,0 +1,123 @@
+package gojtp
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// discriminator requires the key in the objects at the pattern.
+type discriminator struct {
+	// segments of the pattern, unescaped, * matching any.
+	segments []string
+	key      string
+}
+
+// WithRequiredDiscriminator Option
+// Specifies a discriminator key, like "type", the objects at the
+// RFC 6901 JSON Pointer pattern must have, where a * segment matches
+// any key or array index, e.g. "/targets/*" for the objects in the
+// targets array. It can be passed multiple times.
+func WithRequiredDiscriminator(pathPattern string, key string) Option {
+	return func(verifier *Verify) error {
+		if pathPattern != "" && pathPattern[0] != '/' {
+			return fmt.Errorf("jtp: path pattern must start with /"+
+				" %q", pathPattern)
+		}
+		var segments []string
+		if pathPattern != "" {
+			segments = strings.Split(pathPattern[1:], "/")
+		}
+		unescape := strings.NewReplacer("~1", "/", "~0", "~")
+		for i, s := range segments {
+			segments[i] = unescape.Replace(s)
+		}
+		verifier.discriminators = append(verifier.discriminators,
+			discriminator{segments: segments, key: key})
+		return nil
+	}
+}
+
+// discriminatorHandler checks the discriminator keys of the objects,
+// forwarding the events to next if set.
+type discriminatorHandler struct {
+	pathForwarder
+	rules []discriminator
+	// missing has for each open container, in words() words, the
+	// bits of the rules whose key was not seen yet.
+	missing []uint64
+	buf     []byte
+}
+
+// matches reports if the current location matches the segments.
+func (h *discriminatorHandler) matches(segments []string) bool {
+	if len(segments) != h.n {
+		return false
+	}
+	for i, f := range h.frames[:h.n] {
+		switch {
+		case segments[i] == "*":
+		case f.array:
+			h.buf = strconv.AppendInt(h.buf[:0], int64(f.index), 10)
+			if string(h.buf) != segments[i] {
+				return false
+			}
+		case string(f.key) != segments[i]:
+			return false
+		}
+	}
+	return true
+}
+
+// words returns the number of words of the bits of the rules.
+func (h *discriminatorHandler) words() int {
+	return (len(h.rules) + 63) / 64
+}
+
+func (h *discriminatorHandler) begin(c byte, i int) error {
+	h.pathTracker.next()
+	n := len(h.missing)
+	for k := 0; k < h.words(); k++ {
+		h.missing = append(h.missing, 0)
+	}
+	if c == '{' {
+		missing := h.missing[n:]
+		for j, rule := range h.rules {
+			if h.matches(rule.segments) {
+				missing[j/64] |= 1 << uint(j%64)
+			}
+		}
+	}
+	h.push(c)
+	return h.forwarder.begin(c, i)
+}
+
+func (h *discriminatorHandler) end(c byte, i int) error {
+	h.pathTracker.end()
+	n := len(h.missing) - h.words()
+	missing := h.missing[n:]
+	h.missing = h.missing[:n]
+	for _, m := range missing {
+		if m != 0 {
+			return fmt.Errorf("jtp.missingDiscriminator.Path-[%s]",
+				h.appendPointer(nil))
+		}
+	}
+	return h.forwarder.end(c, i)
+}
+
+func (h *discriminatorHandler) key(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.key(data, startIndex, endIndex)
+	missing := h.missing[len(h.missing)-h.words():]
+	for k, m := range missing {
+		for ; m != 0; m &= m - 1 {
+			b := bits.TrailingZeros64(m)
+			if stringEquals(data, startIndex, endIndex,
+				h.rules[k*64+b].key) {
+				missing[k] &^= 1 << uint(b)
+			}
+		}
+	}
+	return h.forwarder.key(data, startIndex, endIndex)
+}
@@ -0,0 +1,82 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRequiredDiscriminator(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRequiredDiscriminator("/targets/*", "type"))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "every target typed",
+			json: `{"targets": [{"type": "a", "x": {}}, {"y": 1, "type": "b"}], "other": [{}]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "untyped target",
+			json: `{"targets": [{"type": "a"}, {"kind": "b"}]}`,
+			err:  fmt.Errorf("jtp.missingDiscriminator.Path-[/targets/1]"),
+			ok:   false,
+		},
+		{
+			name: "type nested deeper",
+			json: `{"targets": [{"spec": {"type": "a"}}]}`,
+			err:  fmt.Errorf("jtp.missingDiscriminator.Path-[/targets/0]"),
+			ok:   false,
+		},
+		{
+			name: "wildcard matches keys",
+			json: `{"targets": {"first": {"type": 1}, "second": {}}}`,
+			err:  fmt.Errorf("jtp.missingDiscriminator.Path-[/targets/second]"),
+			ok:   false,
+		},
+		{
+			name: "scalar targets",
+			json: `{"targets": [1, "a", null]}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	verifier, _ = New(WithRequiredDiscriminator("", "version"))
+	ok, err := verifier.VerifyString(`{}`)
+	checkResult(t, false, ok, fmt.Errorf("jtp.missingDiscriminator.Path-[]"), err)
+	if _, err := New(WithRequiredDiscriminator("targets", "type")); err == nil {
+		t.Errorf("Expected an error for a pattern without a leading /")
+	}
+}
+
+func TestRequiredDiscriminatorMerged(t *testing.T) {
+	t.Parallel()
+	var a, b []Option
+	for i := 0; i < 80; i++ {
+		opt := WithRequiredDiscriminator(fmt.Sprintf("/k%d", i), "type")
+		if i < 40 {
+			a = append(a, opt)
+		} else {
+			b = append(b, opt)
+		}
+	}
+	va, _ := New(a...)
+	vb, _ := New(b...)
+	m := Merge(va.(Verify), vb.(Verify))
+	ok, err := m.VerifyString(`{"k70": {}}`)
+	checkResult(t, false, ok,
+		fmt.Errorf("jtp.missingDiscriminator.Path-[/k70]"), err)
+	ok, err = m.VerifyString(`{"k70": {"type": 1}, "k3": {"type": 2}}`)
+	checkResult(t, true, ok, nil, err)
+}
@@ -0,0 +1,135 @@
+package gojtp
+
+import "fmt"
+
+// WithFlagEmbeddedJSON Option
+// Rejects any string value looking like an embedded JSON, i.e. whose
+// decoded content starts with { or [ after the whitespace, with
+// jtp.embeddedJSONDetected.Path-[/a/0] to locate it. The content is
+// not parsed, so "[not json" is flagged too, see
+// WithMaxEmbeddedJSONDepth to parse it.
+func WithFlagEmbeddedJSON() Option {
+	return func(verifier *Verify) error {
+		verifier.FlagEmbeddedJSON = true
+		return nil
+	}
+}
+
+// WithMaxEmbeddedJSONDepth Option
+// Specifies the maximum nesting of JSON embedded in string values,
+// e.g. "{\"a\": 1}" is an embedded JSON of depth 1 and a string value
+// of it holding an object or array as well has a depth of 2.
+// Only the objects and arrays count as embedded JSON, not "1" or
+// "\"a\"". The Found is at most Max+1, as deeper levels aren't parsed.
+//
+// Each string value which may be a JSON is decoded and parsed, so the
+// cost of a JSON made of such values is a multiple of the plain
+// verification.
+// zero value disable the checks
+func WithMaxEmbeddedJSONDepth(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max embedded json depth cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxEmbeddedJSONDepth = l
+		verifier.embeddedJSONEnabled = true
+		return nil
+	}
+}
+
+// embeddedHandler finds the deepest JSON embedded in the string
+// values of a walk, parsing at most limit levels.
+type embeddedHandler struct {
+	limit int
+	depth int
+	buf   []byte
+}
+
+func (h *embeddedHandler) begin(c byte, i int) error {
+	return nil
+}
+
+func (h *embeddedHandler) end(c byte, i int) error {
+	return nil
+}
+
+func (h *embeddedHandler) key(data []byte, startIndex, endIndex int) error {
+	return nil
+}
+
+func (h *embeddedHandler) value(data []byte, startIndex, endIndex int) error {
+	if h.depth >= h.limit || data[startIndex] != '"' {
+		return nil
+	}
+	h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+	maxInt(&h.depth, embeddedJSONDepth(h.buf, h.limit))
+	return nil
+}
+
+// embeddedJSONDepth returns the depth of the JSON embedded in the
+// decoded string content str, at most limit.
+func embeddedJSONDepth(str []byte, limit int) int {
+	if limit == 0 {
+		return 0
+	}
+	if i := skipSpace(str, 0); i == len(str) ||
+		str[i] != '{' && str[i] != '[' {
+		return 0
+	}
+	h := &embeddedHandler{limit: limit - 1}
+	s := state{h: h}
+	if ok, _ := s.verify(str); !ok {
+		return 0
+	}
+	return 1 + h.depth
+}
+
+// mayBeJSON reports if the decoded content of the valid string span
+// starts with { or [ after the whitespace, as an embedded JSON does.
+func mayBeJSON(data []byte, startIndex, endIndex int) bool {
+	for i := startIndex + 1; i < endIndex-1; {
+		r, size := decodeStringRune(data, i)
+		switch r {
+		case ' ', '\t', '\n', '\r':
+			i += size
+			continue
+		}
+		return r == '{' || r == '['
+	}
+	return false
+}
+
+// checkEmbeddedJSON checks the JSON embedded in the valid string value
+// span.
+func (s *state) checkEmbeddedJSON(data []byte, startIndex,
+	endIndex int) error {
+	if !mayBeJSON(data, startIndex, endIndex) {
+		return nil
+	}
+	s.embedded = appendDecodedString(s.embedded[:0], data, startIndex,
+		endIndex)
+	max := s.verifier.MaxEmbeddedJSONDepth
+	if depth := embeddedJSONDepth(s.embedded, max+1); depth > max {
+		return newLimitError(embeddedDepth, max, depth, startIndex)
+	}
+	return nil
+}
+
+// embeddedFlagHandler rejects the string values looking like an
+// embedded JSON, forwarding the events to next if set.
+type embeddedFlagHandler struct {
+	pathForwarder
+}
+
+func (h *embeddedFlagHandler) value(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.next()
+	if data[startIndex] == '"' && mayBeJSON(data, startIndex, endIndex) {
+		return fmt.Errorf("jtp.embeddedJSONDetected.Path-[%s]",
+			h.appendPointer(nil))
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
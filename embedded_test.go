@@ -0,0 +1,97 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMaxEmbeddedJSONDepth(t *testing.T) {
+	t.Parallel()
+	doubly := `{"a": "{\"x\": \"[1, {\\\"y\\\": 2}]\"}"}`
+	scenarios := []struct {
+		name  string
+		json  string
+		limit int
+		err   error
+		ok    bool
+	}{
+		{
+			name:  "embedded once",
+			json:  `{"a": "plain", "b": " {\"x\": [1, \"2\"]}"}`,
+			limit: 1,
+			err:   nil,
+			ok:    true,
+		},
+		{
+			name:  "embedded twice",
+			json:  doubly,
+			limit: 1,
+			err:   fmt.Errorf("jtp.maxEmbeddedJSONDepthReached.Max-[1]-Allowed.Found-[2]"),
+			ok:    false,
+		},
+		{name: "embedded twice within limit", json: doubly, limit: 2, ok: true},
+		{
+			name:  "escaped brace",
+			json:  `["\u007b\"a\": \"[\\\"x\\\"]\"}"]`,
+			limit: 1,
+			err:   fmt.Errorf("jtp.maxEmbeddedJSONDepthReached.Max-[1]-Allowed.Found-[2]"),
+			ok:    false,
+		},
+		{
+			name:  "not json",
+			json:  `["{not json", "\n[1, \"[2]\"", "123", "\"[1]\""]`,
+			limit: 1,
+			err:   nil,
+			ok:    true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier, _ := New(WithMaxEmbeddedJSONDepth(tc.limit))
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestFlagEmbeddedJSON(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithFlagEmbeddedJSON())
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "embedded object",
+			json: `{"a": ["ok", "{\"x\":1}"]}`,
+			err:  fmt.Errorf("jtp.embeddedJSONDetected.Path-[/a/1]"),
+			ok:   false,
+		},
+		{
+			name: "after escaped whitespace",
+			json: `{"b": " \n\u005b1]"}`,
+			err:  fmt.Errorf("jtp.embeddedJSONDetected.Path-[/b]"),
+			ok:   false,
+		},
+		{
+			name: "top level string",
+			json: `"[not json"`,
+			err:  fmt.Errorf("jtp.embeddedJSONDetected.Path-[]"),
+			ok:   false,
+		},
+		{
+			name: "normal strings",
+			json: `{"{key}": "a {b} [c]", "d": " x", "e": "", "f": 1}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
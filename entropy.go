@@ -0,0 +1,88 @@
+package gojtp
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultMinEntropyStringLength is the default length in bytes of the
+// shortest string value checked by WithMaxStringValueEntropy.
+const DefaultMinEntropyStringLength = 32
+
+// WithMaxStringValueEntropy Option
+// Specifies the maximum Shannon entropy in bits per byte of a string
+// value, over its bytes as written, to reject the values made of
+// seemingly random bytes like the base64 of an encrypted or binary
+// blob, with jtp.highEntropyStringValue.Path-[/a/0]. English text
+// is about 4 to 4.5 bits per byte, base64 of random bytes close to 6.
+// As a string of n bytes has at most log2(n) bits per byte, only the
+// values of MinEntropyStringLength bytes or more are checked, see
+// WithMinEntropyStringLength.
+// zero value disable the checks
+func WithMaxStringValueEntropy(bits float64) Option {
+	return func(verifier *Verify) error {
+		if bits == 0 {
+			return nil
+		}
+		if !(bits > 0) || math.IsInf(bits, 1) {
+			return fmt.Errorf("jtp: max string value entropy must be"+
+				" positive and finite %g", bits)
+		}
+		verifier.MaxStringValueEntropy = bits
+		if verifier.MinEntropyStringLength == 0 {
+			verifier.MinEntropyStringLength = DefaultMinEntropyStringLength
+		}
+		verifier.entropyEnabled = true
+		return nil
+	}
+}
+
+// WithMinEntropyStringLength Option
+// Specifies the length in bytes of the shortest string value checked
+// by WithMaxStringValueEntropy.
+func WithMinEntropyStringLength(l int) Option {
+	return func(verifier *Verify) error {
+		if l <= 0 {
+			return fmt.Errorf("jtp: min entropy string length must be"+
+				" positive %d", l)
+		}
+		verifier.MinEntropyStringLength = l
+		return nil
+	}
+}
+
+// entropyHandler checks the entropy of the string values, forwarding
+// the events to next if set.
+type entropyHandler struct {
+	pathForwarder
+	max    float64
+	minLen int
+	counts [256]int
+}
+
+// entropy returns the Shannon entropy in bits per byte of b.
+func (h *entropyHandler) entropy(b []byte) float64 {
+	for _, c := range b {
+		h.counts[c]++
+	}
+	var bits float64
+	n := float64(len(b))
+	for _, c := range b {
+		if count := h.counts[c]; count > 0 {
+			p := float64(count) / n
+			bits -= p * math.Log2(p)
+			h.counts[c] = 0
+		}
+	}
+	return bits
+}
+
+func (h *entropyHandler) value(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.next()
+	if data[startIndex] == '"' && endIndex-startIndex-2 >= h.minLen &&
+		h.entropy(data[startIndex+1:endIndex-1]) > h.max {
+		return fmt.Errorf("jtp.highEntropyStringValue.Path-[%s]",
+			h.appendPointer(nil))
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
@@ -0,0 +1,54 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMaxStringValueEntropy(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringValueEntropy(5))
+	blob := "q8Zr3XvLw0Tn+Yb5HkJ2uPd7Gf/AeM1sRcV9oWiN4tOx6lKmBgSyQjUzD"
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "base64 blob",
+			json: `{"user": {"notes": ["hi", "` + blob + `"]}}`,
+			err:  fmt.Errorf("jtp.highEntropyStringValue.Path-[/user/notes/1]"),
+			ok:   false,
+		},
+		{
+			name: "natural language",
+			json: `{"notes": "the quick brown fox jumps over the lazy dog and then some more"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "short random value",
+			json: `{"id": "q8Zr3XvLw0Tn+Yb5"}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	t.Run("min length", func(t *testing.T) {
+		verifier, _ := New(WithMaxStringValueEntropy(3.5),
+			WithMinEntropyStringLength(16))
+		ok, err := verifier.VerifyString(`["q8Zr3XvLw0Tn+Yb5", "short"]`)
+		checkResult(t, false, ok,
+			fmt.Errorf("jtp.highEntropyStringValue.Path-[/0]"), err)
+	})
+	if _, err := New(WithMinEntropyStringLength(0)); err == nil {
+		t.Errorf("Expected an error for a zero min entropy string length")
+	}
+}
@@ -0,0 +1,46 @@
+package gojtp
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// epochRule requires the integers under key to be within min and max.
+type epochRule struct {
+	key      string
+	min, max int64
+}
+
+// WithEpochRangeForKeys Option
+// Specifies the range, in Unix seconds, of the integer number values
+// directly under key, e.g. 946684800 and 4102444800 for the years
+// 2000 to 2100, failing with jtp.epochOutOfRange.Key-[ts] otherwise,
+// like a timestamp in milliseconds. Only the integer literals are
+// checked, the other values under key are not.
+// It can be passed multiple times, the first matching key applies.
+func WithEpochRangeForKeys(key string, minSec, maxSec int64) Option {
+	return func(verifier *Verify) error {
+		if minSec > maxSec {
+			return fmt.Errorf("jtp: epoch range min %d greater than"+
+				" max %d", minSec, maxSec)
+		}
+		verifier.epochRanges = append(verifier.epochRanges,
+			epochRule{key: key, min: minSec, max: maxSec})
+		return nil
+	}
+}
+
+// validateEpoch checks the valid number token under the key of rule.
+func validateEpoch(token []byte, rule *epochRule) error {
+	for _, c := range token {
+		if c == '.' || c == 'e' || c == 'E' {
+			return nil
+		}
+	}
+	// out of the int64 range is out of any range
+	n, err := strconv.ParseInt(string(token), 10, 64)
+	if err == nil && rule.min <= n && n <= rule.max {
+		return nil
+	}
+	return fmt.Errorf("jtp.epochOutOfRange.Key-[%s]", rule.key)
+}
@@ -0,0 +1,71 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEpochRangeForKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithEpochRangeForKeys("ts", 946684800, 4102444800))
+	outOfRange := fmt.Errorf("jtp.epochOutOfRange.Key-[ts]")
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "valid timestamp",
+			json: `{"ts": 1700000000, "n": -5}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "milliseconds",
+			json: `{"ts": 1700000000000}`,
+			err:  outOfRange,
+			ok:   false,
+		},
+		{
+			name: "negative",
+			json: `{"a": {"ts": -1700000000}}`,
+			err:  outOfRange,
+			ok:   false,
+		},
+		{
+			name: "beyond int64",
+			json: `{"ts": 99999999999999999999}`,
+			err:  outOfRange,
+			ok:   false,
+		},
+		{
+			name: "not an integer",
+			json: `{"ts": "1", "x": 1.5e3, "y": [{"ts": 1.5}], "z": [1]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "only directly under the key",
+			json: `{"ts": [1], "b": 2}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ok, err := verifier.VerifyBytes([]byte(tc.json))
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestEpochRangeForKeysOption(t *testing.T) {
+	t.Parallel()
+	_, err := New(WithEpochRangeForKeys("ts", 10, 5))
+	if err == nil {
+		t.Errorf("expected an error for a min greater than max")
+	}
+}
@@ -0,0 +1,84 @@
+package gojtp
+
+import "fmt"
+
+// LimitError is returned when a configured limit is exceeded.
+// Use errors.As to inspect it.
+type LimitError struct {
+	// Kind of the limit, like maxArrayElementCountReached.
+	Kind string
+	// Max allowed by the limit.
+	Max int
+	// Found value exceeding Max.
+	Found int
+	// Offset is the byte offset in the JSON where the limit was
+	// exceeded, e.g. for maxArrayElementCountReached the first
+	// disallowed element at the array index Found-1.
+	Offset int
+	// Path is the RFC 6901 JSON Pointer of the container exceeding
+	// the limit, set for maxContainerDepthReached and
+	// maxObjectEntryCountReached, of the object holding the key for
+	// the key length limits and of the string for the string value
+	// length limits, empty for the other limits.
+	Path string
+	// Container is the type, TypeObject or TypeArray, of the
+	// container exceeding the limit, set for
+	// maxContainerDepthReached only.
+	Container Type
+}
+
+func (e *LimitError) Error() string {
+	if e.Container != 0 {
+		return fmt.Sprintf("jtp.%s.Type-[%s].Max-[%d]-Allowed.Found-[%d]",
+			e.Kind, e.Container, e.Max, e.Found)
+	}
+	return fmt.Sprintf("jtp.%s.Max-[%d]-Allowed.Found-[%d]",
+		e.Kind, e.Max, e.Found)
+}
+
+// MalformedError is returned for the common corruptions of the
+// commas of a malformed JSON, like the trailing comma of [1,], and
+// for a JSON beginning with a structural byte, like }, to help
+// debugging the producer. It matches ErrInvalidJSON with
+// errors.Is, the other malformed JSON fail with ErrInvalidJSON.
+type MalformedError struct {
+	// Reason of the error, like trailingComma.
+	Reason string
+	// Offset is the byte offset in the JSON of the unexpected comma
+	// or byte, or of the missing value.
+	Offset int
+}
+
+func (e *MalformedError) Error() string {
+	return fmt.Sprintf("jtp.%s.Offset-[%d]", e.Reason, e.Offset)
+}
+
+// Is reports if target is ErrInvalidJSON.
+func (e *MalformedError) Is(target error) bool {
+	return target == ErrInvalidJSON
+}
+
+// RejectedError is returned when a value is rejected by a validator
+// set with WithNumberValidator or WithStringValidator. Use errors.As
+// to inspect it, it wraps the error of the validator.
+type RejectedError struct {
+	// Kind of the rejected value, number, string or key.
+	Kind string
+	// Offset is the byte offset in the JSON of the rejected value.
+	Offset int
+	// Err is the error returned by the validator.
+	Err error
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("jtp.%sRejected.Offset-[%d].Reason-[%v]",
+		e.Kind, e.Offset, e.Err)
+}
+
+func (e *RejectedError) Unwrap() error {
+	return e.Err
+}
+
+func newLimitError(kind string, max, found, offset int) error {
+	return &LimitError{Kind: kind, Max: max, Found: found, Offset: offset}
+}
@@ -0,0 +1,94 @@
+package gojtp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArrayElementCountOffset(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(2))
+	json := `{"a": [10, 20,  30]}`
+	_, err := verifier.VerifyString(json)
+	var le *LimitError
+	if !errors.As(err, &le) {
+		t.Fatalf("Expected an error of type *LimitError Got %v", err)
+	}
+	if le.Kind != "maxArrayElementCountReached" || le.Max != 2 ||
+		le.Found != 3 {
+		t.Errorf("Unexpected error %v", le)
+	}
+	if index := le.Found - 1; index != 2 {
+		t.Errorf("Expected index 2 Got %d", index)
+	}
+	if le.Offset != 16 || json[le.Offset:le.Offset+2] != "30" {
+		t.Errorf("Expected offset 16 of the first disallowed element"+
+			" Got %d", le.Offset)
+	}
+}
+
+func TestContainerDepthType(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(2))
+	scenarios := []struct {
+		name      string
+		json      string
+		container Type
+		msg       string
+	}{
+		{
+			name:      "array deep",
+			json:      `{"a": [[1]]}`,
+			container: TypeArray,
+			msg:       "jtp.maxContainerDepthReached.Type-[array].Max-[2]-Allowed.Found-[3]",
+		},
+		{
+			name:      "object deep",
+			json:      `[{"a": {"b": 1}}]`,
+			container: TypeObject,
+			msg:       "jtp.maxContainerDepthReached.Type-[object].Max-[2]-Allowed.Found-[3]",
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.VerifyString(tc.json)
+			var le *LimitError
+			if !errors.As(err, &le) {
+				t.Fatalf("Expected an error of type *LimitError Got %v", err)
+			}
+			if le.Container != tc.container || le.Error() != tc.msg {
+				t.Errorf("Expected %v %s Got %v %s", tc.container, tc.msg,
+					le.Container, le.Error())
+			}
+		})
+	}
+}
+
+func TestMalformedCommas(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New()
+	scenarios := []struct {
+		json string
+		err  string
+	}{
+		{json: `[1,,2]`, err: "jtp.emptyArrayElement.Offset-[3]"},
+		{json: `[,1]`, err: "jtp.emptyArrayElement.Offset-[1]"},
+		{json: `[1,]`, err: "jtp.trailingComma.Offset-[2]"},
+		{json: `[1, ]`, err: "jtp.trailingComma.Offset-[2]"},
+		{json: `{"a": 1, }`, err: "jtp.trailingComma.Offset-[7]"},
+		{json: `{"a":,}`, err: "jtp.emptyObjectValue.Offset-[5]"},
+		{json: `{"a": }`, err: "jtp.emptyObjectValue.Offset-[6]"},
+		{json: `[1 2]`, err: "jtp.MalformedJSON"},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			if ok || err == nil || err.Error() != tc.err {
+				t.Errorf("Expected %s Got %v %v", tc.err, ok, err)
+			}
+			if !errors.Is(err, ErrInvalidJSON) {
+				t.Errorf("Expected the error to match ErrInvalidJSON")
+			}
+		})
+	}
+}
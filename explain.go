@@ -0,0 +1,236 @@
+package gojtp
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// observed holds the maximum value seen in a JSON for each limit.
+type observed struct {
+	arrayElements     int
+	objectEntries     int
+	keyLength         int
+	topLevelKeyLength int
+	stringLength      int
+	stringWeight      int
+}
+
+// key records the decoded length of an object key and the entry
+// count of its object.
+func (o *observed) key(l, entries, depth int) {
+	maxInt(&o.objectEntries, entries)
+	maxInt(&o.keyLength, l)
+	if depth == 1 {
+		maxInt(&o.topLevelKeyLength, l)
+	}
+}
+
+// stringValue records a string value span.
+func (o *observed) stringValue(data []byte, startIndex, endIndex int) {
+	maxInt(&o.stringLength, decodedRuneCount(data, startIndex, endIndex))
+	maxInt(&o.stringWeight, decodedByteLength(data, startIndex, endIndex))
+}
+
+func maxInt(max *int, n int) {
+	if n > *max {
+		*max = n
+	}
+}
+
+// LimitReport describes the maximum value observed in a JSON
+// for a configured limit.
+type LimitReport struct {
+	// Name of the limit, as the Verify field.
+	Name     string
+	Limit    int
+	Observed int
+}
+
+// Exceeded reports if the observed value breaks the limit.
+func (l LimitReport) Exceeded() bool {
+	return l.Observed > l.Limit
+}
+
+func (l LimitReport) String() string {
+	return fmt.Sprintf("%s seen: %d / limit %d", l.Name, l.Observed,
+		l.Limit)
+}
+
+// Report lists, for each enabled limit of a Verify, how close
+// a JSON came to it.
+type Report struct {
+	Limits []LimitReport
+}
+
+// Explain walks the json in a dry-run without enforcing any limit
+// and returns a Report with the maximum observed value of each
+// limit enabled on v, regardless of pass or fail.
+// The returned error is non nil only if the json is malformed,
+// in which case the Report covers the part walked.
+func (v Verify) Explain(json []byte) (Report, error) {
+	var o observed
+	s := state{verifier: Verify{}, observed: &o}
+	_, err := s.verify(json)
+
+	var r Report
+	add := func(enabled bool, name string, limit, observed int) {
+		if enabled {
+			r.Limits = append(r.Limits, LimitReport{Name: name,
+				Limit: limit, Observed: observed})
+		}
+	}
+	add(v.jsonContainerDepthEnabled, "JSONContainerDepth",
+		v.JSONContainerDepth, s.maxDepth)
+	add(v.arrayEntryCountEnabled, "MaxArrayElementCount",
+		v.MaxArrayElementCount, o.arrayElements)
+	add(v.objectEntryCountEnabled, "ObjectEntryCount",
+		v.ObjectEntryCount, o.objectEntries)
+	add(v.objectKeyLengthEnabled, "ObjectKeyLength",
+		v.ObjectKeyLength, o.keyLength)
+	add(v.topLevelKeyLengthEnabled, "TopLevelKeyLength",
+		v.TopLevelKeyLength, o.topLevelKeyLength)
+	add(v.stringLenEnabled, "StringValueLen",
+		v.StringValueLen, o.stringLength)
+	add(v.stringWeightEnabled, "StringValueWeight",
+		v.StringValueWeight, o.stringWeight)
+	return r, err
+}
+
+// VerifyWithinEnvelope returns true if the candidate json passes v
+// and is no bigger than the reference json in any dimension: its
+// container depth, array element count, object entry count, key
+// length and string value length are each at most the maximum seen
+// in the reference, e.g. to derive the limits from a known good
+// example. A dimension absent from the reference, like the arrays of
+// an object without any, allows none in the candidate.
+// A malformed reference fails with its error.
+func (v Verify) VerifyWithinEnvelope(reference, candidate []byte) (bool,
+	error) {
+	var o observed
+	s := state{verifier: Verify{}, observed: &o}
+	if ok, err := s.verify(reference); !ok || err != nil {
+		return ok, err
+	}
+	envelope := Verify{
+		JSONContainerDepth:        s.maxDepth,
+		jsonContainerDepthEnabled: true,
+		MaxArrayElementCount:      o.arrayElements,
+		arrayEntryCountEnabled:    true,
+		ObjectEntryCount:          o.objectEntries,
+		objectEntryCountEnabled:   true,
+		ObjectKeyLength:           o.keyLength,
+		objectKeyLengthEnabled:    true,
+		StringValueLen:            o.stringLength,
+		stringLenEnabled:          true,
+	}
+	return Merge(v, envelope).VerifyBytes(candidate)
+}
+
+// MaxDepth walks the json without enforcing any limit and returns
+// the maximum container depth reached, as counted by
+// WithMaxContainerDepth, without building a Report. The limits of v
+// are not used, the depth being measured to choose one.
+// The returned error is non nil only if the json is malformed,
+// in which case the depth covers the part walked.
+func (v Verify) MaxDepth(json []byte) (int, bool, error) {
+	s := state{verifier: Verify{}}
+	ok, err := s.verify(json)
+	return s.maxDepth, ok, err
+}
+
+// accumulatorSamples is the number of documents an Accumulator
+// keeps, bounding its memory and the cost of Recommend.
+const accumulatorSamples = 1024
+
+// Accumulator aggregates the maximum values seen in many JSON
+// documents by Observe, to recommend limits from real traffic.
+// It keeps a uniform random sample of at most 1024 documents, so
+// that the percentiles of a long stream are estimated in bounded
+// memory, and exact while fewer documents are observed.
+// The zero value is ready to use.
+type Accumulator struct {
+	// documents added so far
+	count int64
+	// per document maxima, in the order depth, array element count,
+	// object entry count, key length and string value length
+	samples [][5]int
+}
+
+// Observe walks the json in a dry-run, as Explain, and adds its
+// maximum container depth, array element count, object entry count,
+// key length and string value length to acc. A malformed json is not
+// added. No limit of v is enforced, as for the limits it recommends.
+func (v Verify) Observe(json []byte, acc *Accumulator) {
+	var o observed
+	s := state{verifier: Verify{}, observed: &o}
+	if ok, err := s.verify(json); !ok || err != nil {
+		return
+	}
+	maxima := [5]int{s.maxDepth, o.arrayElements, o.objectEntries,
+		o.keyLength, o.stringLength}
+	acc.count++
+	if len(acc.samples) < accumulatorSamples {
+		acc.samples = append(acc.samples, maxima)
+		return
+	}
+	// reservoir sampling, each document is kept with the same
+	// probability
+	if j := rand.Int63n(acc.count); j < accumulatorSamples {
+		acc.samples[j] = maxima
+	}
+}
+
+// Recommendation is a set of limits recommended by an Accumulator,
+// named as the Verify fields, zero for a limit never reached.
+type Recommendation struct {
+	JSONContainerDepth   int
+	MaxArrayElementCount int
+	ObjectEntryCount     int
+	ObjectKeyLength      int
+	StringValueLen       int
+}
+
+// Options returns the Option of each limit of r, zero limits
+// being disabled, to pass to New.
+func (r Recommendation) Options() []Option {
+	return []Option{WithMaxContainerDepth(r.JSONContainerDepth),
+		WithMaxArrayElementCount(r.MaxArrayElementCount),
+		WithMaxObjectEntryCount(r.ObjectEntryCount),
+		WithMaxObjectKeyLength(r.ObjectKeyLength),
+		WithMaxStringLength(r.StringValueLen)}
+}
+
+// Recommend returns for each limit the percentile, in (0, 100], of
+// the maxima of the documents observed, e.g. 100 for limits passing
+// all of them, or 99 to leave out the outliers.
+func (a *Accumulator) Recommend(percentile float64) Recommendation {
+	return Recommendation{
+		JSONContainerDepth:   a.percentile(0, percentile),
+		MaxArrayElementCount: a.percentile(1, percentile),
+		ObjectEntryCount:     a.percentile(2, percentile),
+		ObjectKeyLength:      a.percentile(3, percentile),
+		StringValueLen:       a.percentile(4, percentile),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of the maxima at
+// index i of the samples.
+func (a *Accumulator) percentile(i int, p float64) int {
+	if len(a.samples) == 0 {
+		return 0
+	}
+	sorted := make([]int, len(a.samples))
+	for j, maxima := range a.samples {
+		sorted[j] = maxima[i]
+	}
+	sort.Ints(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	} else if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,187 @@
+package gojtp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	b := _getTestJSONBytes()
+	verifier, _ := New(WithMaxArrayElementCount(6),
+		WithMaxContainerDepth(7),
+		WithMaxObjectKeyLength(20), WithMaxStringLength(25),
+		WithMaxObjectEntryCount(5))
+	v := verifier.(Verify)
+
+	r, err := v.Explain(b)
+	if err != nil {
+		t.Fatalf("Expected an nil error Got - %v", err)
+	}
+	expected := []LimitReport{
+		{Name: "JSONContainerDepth", Limit: 7, Observed: 7},
+		{Name: "MaxArrayElementCount", Limit: 6, Observed: 6},
+		{Name: "ObjectEntryCount", Limit: 5, Observed: 5},
+		{Name: "ObjectKeyLength", Limit: 20, Observed: 19},
+		{Name: "StringValueLen", Limit: 25, Observed: 47},
+	}
+	if !reflect.DeepEqual(r.Limits, expected) {
+		t.Errorf("Expected report %v Got %v", expected, r.Limits)
+	}
+	for _, l := range r.Limits {
+		if l.Exceeded() != (l.Name == "StringValueLen") {
+			t.Errorf("Unexpected Exceeded %v for %s", l.Exceeded(), l)
+		}
+	}
+	if s := r.Limits[0].String(); s != "JSONContainerDepth seen: 7 / limit 7" {
+		t.Errorf("Unexpected String %s", s)
+	}
+}
+
+func TestExplainMalformed(t *testing.T) {
+	t.Parallel()
+	_, err := Verify{}.Explain(_getMalformedTestJSONBytes())
+	if err != ErrInvalidJSON {
+		t.Errorf("Expected Error of kind ErrInvalidJSON Got %v", err)
+	}
+}
+
+func TestVerifyWithinEnvelope(t *testing.T) {
+	t.Parallel()
+	reference := []byte(`{"name": "abcdef", "tags": ["x", "y"], "meta": {"id": 1}}`)
+	verifier, _ := New(WithMaxStringLength(4))
+	scenarios := []struct {
+		name      string
+		candidate string
+		err       error
+		ok        bool
+	}{
+		{
+			name:      "within",
+			candidate: `{"name": "abcd", "tags": ["z"], "meta": {"id": 2}}`,
+			err:       nil,
+			ok:        true,
+		},
+		{
+			name:      "deeper",
+			candidate: `{"name": "abc", "meta": {"id": {"x": 1}}}`,
+			err:       fmt.Errorf("jtp.maxContainerDepthReached.Type-[object].Max-[2]-Allowed.Found-[3]"),
+			ok:        false,
+		},
+		{
+			name:      "more elements",
+			candidate: `{"tags": ["a", "b", "c"]}`,
+			err:       fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:        false,
+		},
+		{
+			name:      "stricter verifier",
+			candidate: `{"name": "abcde"}`,
+			err:       fmt.Errorf("jtp.maxStringValueLengthReached.Max-[4]-Allowed.Found-[5]"),
+			ok:        false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.(Verify).VerifyWithinEnvelope(reference,
+				[]byte(tc.candidate))
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	ok, err := verifier.(Verify).VerifyWithinEnvelope([]byte(`"scalar"`),
+		[]byte(`[]`))
+	checkResult(t, false, ok, fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[0]-Allowed.Found-[1]"), err)
+}
+
+func TestMaxDepth(t *testing.T) {
+	t.Parallel()
+	b := _getTestJSONBytes()
+	depth, ok, err := Verify{}.MaxDepth(b)
+	checkResult(t, true, ok, nil, err)
+	// the fixture nests six containers under its top level object
+	if depth != 7 {
+		t.Errorf("Expected depth 7 Got %d", depth)
+	}
+	for _, l := range []int{depth, depth - 1} {
+		verifier, _ := New(WithMaxContainerDepth(l))
+		ok, _ := verifier.VerifyBytes(b)
+		if ok != (l == depth) {
+			t.Errorf("Expected WithMaxContainerDepth(%d) to agree with"+
+				" depth %d", l, depth)
+		}
+	}
+
+	depth, ok, err = Verify{}.MaxDepth([]byte(`[[{"a": [`))
+	if ok || err == nil || depth != 4 {
+		t.Errorf("Expected partial depth 4 and an error Got %d %v %v",
+			depth, ok, err)
+	}
+}
+
+func TestMaxDepthAllocs(t *testing.T) {
+	b := _getTestJSONBytes()
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _, _ = Verify{}.MaxDepth(b)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocation Got %v", allocs)
+	}
+}
+
+func TestAccumulatorRecommend(t *testing.T) {
+	t.Parallel()
+	docs := []string{
+		`{"id": 1, "tags": ["a", "b"]}`,
+		`{"id": 2, "tags": ["a", "b", "c"], "name": "longer name"}`,
+		`[[[{"deep": true}]]]`,
+		`{"key with a long name": [1, 2, 3, 4, 5, 6, 7]}`,
+	}
+	var acc Accumulator
+	for _, doc := range docs {
+		Verify{}.Observe([]byte(doc), &acc)
+	}
+	// not added
+	Verify{}.Observe([]byte(`{"a": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10`), &acc)
+
+	r := acc.Recommend(100)
+	expected := Recommendation{JSONContainerDepth: 4,
+		MaxArrayElementCount: 7, ObjectEntryCount: 3,
+		ObjectKeyLength: 20, StringValueLen: 11}
+	if r != expected {
+		t.Errorf("Expected %+v Got %+v", expected, r)
+	}
+	verifier, err := New(r.Options()...)
+	if err != nil {
+		t.Fatalf("Expected an nil error Got - %v", err)
+	}
+	for _, doc := range docs {
+		ok, err := verifier.VerifyString(doc)
+		checkResult(t, true, ok, nil, err)
+	}
+
+	// the 50th percentile leaves out the largest half
+	r = acc.Recommend(50)
+	if r.MaxArrayElementCount != 2 || r.JSONContainerDepth != 2 {
+		t.Errorf("Unexpected median recommendation %+v", r)
+	}
+	if r := (&Accumulator{}).Recommend(99); r != (Recommendation{}) {
+		t.Errorf("Expected an empty recommendation Got %+v", r)
+	}
+}
+
+func TestAccumulatorBounded(t *testing.T) {
+	t.Parallel()
+	var acc Accumulator
+	for i := 0; i < 3*accumulatorSamples; i++ {
+		Verify{}.Observe([]byte(`[[1, 2, 3]]`), &acc)
+	}
+	if len(acc.samples) != accumulatorSamples {
+		t.Errorf("Expected %d samples Got %d", accumulatorSamples,
+			len(acc.samples))
+	}
+	r := acc.Recommend(100)
+	if r.JSONContainerDepth != 2 || r.MaxArrayElementCount != 3 {
+		t.Errorf("Unexpected recommendation %+v", r)
+	}
+}
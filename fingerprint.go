@@ -0,0 +1,74 @@
+package gojtp
+
+import (
+	"hash"
+	"hash/fnv"
+)
+
+// fingerprintHandler hashes the structural events of a JSON:
+// the containers, the key names and the scalar value types.
+type fingerprintHandler struct {
+	hash hash.Hash64
+	buf  []byte
+	// tag holds the bytes written before the keys, so p doesn't
+	// escape to the hash.
+	tag []byte
+}
+
+func (h *fingerprintHandler) write(p ...byte) {
+	h.tag = append(h.tag[:0], p...)
+	h.hash.Write(h.tag)
+}
+
+func (h *fingerprintHandler) begin(c byte, i int) error {
+	h.write(c)
+	return nil
+}
+
+func (h *fingerprintHandler) end(c byte, i int) error {
+	h.write(c)
+	return nil
+}
+
+func (h *fingerprintHandler) key(data []byte, startIndex, endIndex int) error {
+	// the decoded key, prefixed by its length so the
+	// concatenation of two keys is not one key.
+	h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+	n := len(h.buf)
+	h.write('k', byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	h.hash.Write(h.buf)
+	return nil
+}
+
+func (h *fingerprintHandler) value(data []byte, startIndex, endIndex int) error {
+	switch data[startIndex] {
+	case '"':
+		h.write('s')
+	case 't', 'f':
+		h.write('b')
+	case 'n':
+		h.write('z')
+	default:
+		h.write('d')
+	}
+	return nil
+}
+
+// VerifyStructuralFingerprint verifies the json as VerifyBytes does
+// and returns a 64-bit FNV-1a hash of its shape: the objects and
+// arrays, the decoded key names in order and the type of each value,
+// e.g. to detect when the clients change the structure of their
+// payload. Two json differing only in their scalar values, or their
+// whitespace and key escapes, have the same fingerprint.
+//
+// The fingerprint is not a cryptographic hash, and is zero
+// when the json fails.
+func (v Verify) VerifyStructuralFingerprint(json []byte) (uint64, bool,
+	error) {
+	h := &fingerprintHandler{hash: fnv.New64a()}
+	s := state{verifier: v, h: h}
+	if ok, err := s.verify(json); !ok || err != nil {
+		return 0, ok, err
+	}
+	return h.hash.Sum64(), true, nil
+}
@@ -0,0 +1,48 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVerifyStructuralFingerprint(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(10))
+	v := verifier.(Verify)
+	fingerprint := func(json string) uint64 {
+		f, ok, err := v.VerifyStructuralFingerprint([]byte(json))
+		checkResult(t, true, ok, nil, err)
+		return f
+	}
+	base := fingerprint(`{"id": 1, "tags": ["a", "b"], "ok": true, "x": null}`)
+	same := []string{
+		`{"id": 2.5e3, "tags": ["c", "d"], "ok": false, "x": null}`,
+		`{ "id" : -7 , "tags" : [ "", "longer" ] , "ok" : true , "x" : null }`,
+		`{"\u0069d": 1, "tags": ["a", "b"], "ok": true, "x": null}`,
+	}
+	for _, json := range same {
+		if f := fingerprint(json); f != base {
+			t.Errorf("Expected the fingerprint of %s to be %d Got %d",
+				json, base, f)
+		}
+	}
+	different := []string{
+		`{"id": "1", "tags": ["a", "b"], "ok": true, "x": null}`,
+		`{"id": 1, "tags": ["a", "b", "c"], "ok": true, "x": null}`,
+		`{"tags": ["a", "b"], "id": 1, "ok": true, "x": null}`,
+		`{"idt": 1, "ags": ["a", "b"], "ok": true, "x": null}`,
+		`{"id": 1, "tags": [["a", "b"]], "ok": true, "x": null}`,
+		`{"id": 1, "tags": ["a", "b"], "ok": true, "x": 0}`,
+	}
+	for _, json := range different {
+		if f := fingerprint(json); f == base {
+			t.Errorf("Expected the fingerprint of %s to differ", json)
+		}
+	}
+	f, ok, err := v.VerifyStructuralFingerprint([]byte(`["abcdefghijk"]`))
+	checkResult(t, false, ok,
+		fmt.Errorf("jtp.maxStringValueLengthReached.Max-[10]-Allowed.Found-[11]"), err)
+	if f != 0 {
+		t.Errorf("Expected a zero fingerprint on failure Got %d", f)
+	}
+}
@@ -0,0 +1,77 @@
+package gojtp
+
+// MaxFlattenedLeaves is the default maximum number of leaves
+// VerifyFlatten maps from a JSON, see WithMaxFlattenedLeaves.
+const MaxFlattenedLeaves = 4096
+
+// flattenHandler maps the path of each leaf to its value.
+type flattenHandler struct {
+	pathTracker
+	data   []byte
+	leaves map[string][]byte
+	max    int
+	// start is the offset of the last container opened, empty is set
+	// until it has a key or value.
+	start int
+	empty bool
+	buf   []byte
+}
+
+// add maps the current path to the value span.
+func (h *flattenHandler) add(startIndex, endIndex int) error {
+	h.buf = h.appendPointer(h.buf[:0])
+	h.leaves[string(h.buf)] = h.data[startIndex:endIndex]
+	if len(h.leaves) > h.max {
+		return newLimitError(flattenedLeaves, h.max, len(h.leaves),
+			startIndex)
+	}
+	return nil
+}
+
+func (h *flattenHandler) begin(c byte, i int) error {
+	h.pathTracker.begin(c)
+	h.start, h.empty = i, true
+	return nil
+}
+
+func (h *flattenHandler) end(c byte, i int) error {
+	h.pathTracker.end()
+	if h.empty {
+		h.empty = false
+		return h.add(h.start, i+1)
+	}
+	return nil
+}
+
+func (h *flattenHandler) key(data []byte, startIndex, endIndex int) error {
+	h.empty = false
+	h.pathTracker.key(data, startIndex, endIndex)
+	return nil
+}
+
+func (h *flattenHandler) value(data []byte, startIndex, endIndex int) error {
+	h.empty = false
+	h.next()
+	return h.add(startIndex, endIndex)
+}
+
+// VerifyFlatten is like VerifyBytes and also returns the leaves of the
+// json by their RFC 6901 JSON Pointer, e.g. to index it: each scalar
+// and each empty object or array mapped to its JSON token as written,
+// a slice of json. A later duplicate key replaces the value of the
+// earlier one.
+//
+// At most MaxFlattenedLeaves leaves are mapped, or the limit set by
+// WithMaxFlattenedLeaves, beyond the validation fails with
+// maxFlattenedLeavesReached.
+func (v Verify) VerifyFlatten(json []byte) (map[string][]byte, bool,
+	error) {
+	h := &flattenHandler{data: json, leaves: make(map[string][]byte),
+		max: MaxFlattenedLeaves}
+	if v.flattenedLeavesEnabled {
+		h.max = v.FlattenedLeaves
+	}
+	s := state{verifier: v, h: h}
+	ok, err := s.verify(json)
+	return h.leaves, ok, err
+}
@@ -0,0 +1,82 @@
+package gojtp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVerifyFlatten(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(4))
+	v := verifier.(Verify)
+
+	json := `{"user": {"name": "ana", "tags": ["a", 2, null]},
+		"a/b": true, "empty": { }, "list": [[], {"x": -1.5e3}]}`
+	leaves, ok, err := v.VerifyFlatten([]byte(json))
+	checkResult(t, true, ok, nil, err)
+	found := make(map[string]string, len(leaves))
+	for path, value := range leaves {
+		found[path] = string(value)
+	}
+	expected := map[string]string{
+		"/user/name":   `"ana"`,
+		"/user/tags/0": `"a"`,
+		"/user/tags/1": `2`,
+		"/user/tags/2": `null`,
+		"/a~1b":        `true`,
+		"/empty":       `{ }`,
+		"/list/0":      `[]`,
+		"/list/1/x":    `-1.5e3`,
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %q Got %q", expected, found)
+	}
+
+	t.Run("top level scalar", func(t *testing.T) {
+		leaves, ok, err := v.VerifyFlatten([]byte(`"x"`))
+		checkResult(t, true, ok, nil, err)
+		if string(leaves[""]) != `"x"` || len(leaves) != 1 {
+			t.Errorf("Expected the root leaf Got %q", leaves)
+		}
+	})
+
+	t.Run("flattening bomb", func(t *testing.T) {
+		json := "[" + strings.TrimSuffix(strings.Repeat("0,",
+			MaxFlattenedLeaves+1), ",") + "]"
+		_, ok, err := v.VerifyFlatten([]byte(json))
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxFlattenedLeavesReached.Max-[%d]-Allowed.Found-[%d]",
+			MaxFlattenedLeaves, MaxFlattenedLeaves+1), err)
+	})
+
+	t.Run("configured limit", func(t *testing.T) {
+		verifier, err := New(WithMaxFlattenedLeaves(3))
+		if err != nil {
+			t.Fatalf("Expected an nil error Got - %v", err)
+		}
+		limited := verifier.(Verify)
+		_, ok, err := limited.VerifyFlatten([]byte(`{"a": [1, 2], "b": {}}`))
+		checkResult(t, true, ok, nil, err)
+		_, ok, err = limited.VerifyFlatten([]byte(`[1, [2, 3], {"a": 4}]`))
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxFlattenedLeavesReached.Max-[3]-Allowed.Found-[4]"), err)
+
+		// raised above the default
+		verifier, _ = New(WithMaxFlattenedLeaves(2 * MaxFlattenedLeaves))
+		raised := verifier.(Verify)
+		json := "[" + strings.TrimSuffix(strings.Repeat("0,",
+			MaxFlattenedLeaves+1), ",") + "]"
+		leaves, ok, err := raised.VerifyFlatten([]byte(json))
+		checkResult(t, true, ok, nil, err)
+		if len(leaves) != MaxFlattenedLeaves+1 {
+			t.Errorf("Expected %d leaves Got %d", MaxFlattenedLeaves+1,
+				len(leaves))
+		}
+
+		if _, err := New(WithMaxFlattenedLeaves(-1)); err == nil {
+			t.Errorf("Expected an error for a negative limit")
+		}
+	})
+}
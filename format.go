@@ -0,0 +1,117 @@
+package gojtp
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Format is a format of string values.
+type Format int
+
+// String value formats.
+const (
+	// FormatUUID is a RFC 4122 UUID, like
+	// 123e4567-e89b-12d3-a456-426614174000, in any case.
+	FormatUUID Format = iota + 1
+	// FormatEmail is a RFC 5322 address without display name, like
+	// ana@example.com.
+	FormatEmail
+	// FormatDateTime is a RFC 3339 date-time, like
+	// 2006-01-02T15:04:05Z.
+	FormatDateTime
+	// FormatIPv4 is a dotted decimal IPv4 address.
+	FormatIPv4
+	// FormatIPv6 is a RFC 4291 IPv6 address.
+	FormatIPv6
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatUUID:
+		return "uuid"
+	case FormatEmail:
+		return "email"
+	case FormatDateTime:
+		return "date-time"
+	case FormatIPv4:
+		return "ipv4"
+	case FormatIPv6:
+		return "ipv6"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// formatRule requires the string values under key to be in format.
+type formatRule struct {
+	key    string
+	format Format
+}
+
+// WithStringFormatForKeys Option
+// Specifies the format of the string values directly under key, e.g.
+// a FormatUUID id, failing with
+// jtp.stringFormatMismatch.Key-[id].Format-[uuid] otherwise. The values
+// are checked once decoded, the other values under key are not.
+// It can be passed multiple times, the first matching key applies.
+func WithStringFormatForKeys(key string, format Format) Option {
+	return func(verifier *Verify) error {
+		if format < FormatUUID || format > FormatIPv6 {
+			return fmt.Errorf("jtp: unknown string format %d", format)
+		}
+		verifier.stringFormats = append(verifier.stringFormats,
+			formatRule{key: key, format: format})
+		return nil
+	}
+}
+
+// validateFormat checks the valid string value span under the key
+// of rule.
+func validateFormat(data []byte, startIndex, endIndex int,
+	rule formatRule) error {
+	value := string(appendDecodedString(nil, data, startIndex, endIndex))
+	var ok bool
+	switch rule.format {
+	case FormatUUID:
+		ok = isUUID(value)
+	case FormatEmail:
+		addr, err := mail.ParseAddress(value)
+		ok = err == nil && addr.Name == "" && addr.Address == value
+	case FormatDateTime:
+		_, err := time.Parse(time.RFC3339Nano, value)
+		ok = err == nil
+	case FormatIPv4:
+		ok = net.ParseIP(value) != nil && !strings.Contains(value, ":")
+	case FormatIPv6:
+		ok = net.ParseIP(value) != nil && strings.Contains(value, ":")
+	}
+	if ok {
+		return nil
+	}
+	return fmt.Errorf("jtp.stringFormatMismatch.Key-[%s].Format-[%s]",
+		rule.key, rule.format)
+}
+
+// isUUID reports if s is a hyphenated UUID.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' ||
+				'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStringFormatForKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithStringFormatForKeys("id", FormatUUID),
+		WithStringFormatForKeys("email", FormatEmail),
+		WithStringFormatForKeys("at", FormatDateTime),
+		WithStringFormatForKeys("v4", FormatIPv4),
+		WithStringFormatForKeys("v6", FormatIPv6))
+	mismatch := func(key, format string) error {
+		return fmt.Errorf("jtp.stringFormatMismatch.Key-[%s].Format-[%s]",
+			key, format)
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "valid uuid",
+			json: `{"id": "123e4567-e89b-12d3-A456-426614174000"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "invalid uuid",
+			json: `{"id": "123e4567-e89b-12d3-a456-42661417400g"}`,
+			err:  mismatch("id", "uuid"),
+			ok:   false,
+		},
+		{
+			name: "escaped uuid",
+			json: `{"id": "\u003123e4567-e89b-12d3-a456-426614174000"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "not a string",
+			json: `{"id": 42, "x": {"id": ["no"]}}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "valid formats",
+			json: `{"email": "ana@example.com", "at": "2006-01-02T15:04:05.5+01:00",
+				"v4": "192.168.0.1", "v6": "2001:db8::1"}`,
+			err: nil,
+			ok:  true,
+		},
+		{
+			name: "display name email",
+			json: `{"email": "Ana <ana@example.com>"}`,
+			err:  mismatch("email", "email"),
+			ok:   false,
+		},
+		{
+			name: "date only",
+			json: `{"at": "2006-01-02"}`,
+			err:  mismatch("at", "date-time"),
+			ok:   false,
+		},
+		{
+			name: "ipv6 as ipv4",
+			json: `{"v4": "::1"}`,
+			err:  mismatch("v4", "ipv4"),
+			ok:   false,
+		},
+		{
+			name: "ipv4 as ipv6",
+			json: `{"v6": "10.0.0.1"}`,
+			err:  mismatch("v6", "ipv6"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithStringFormatForKeys("id", Format(9))); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
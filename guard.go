@@ -0,0 +1,18 @@
+package gojtp
+
+import "encoding/json"
+
+// Guard verifies the data as VerifyBytes does and, only if it passes,
+// decodes it into target with json.Unmarshal, so the threat checks
+// run before any allocation of the decoding. The error is the
+// verification error, or the json.Unmarshal one.
+func (v Verify) Guard(data []byte, target interface{}) error {
+	ok, err := v.VerifyBytes(data)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidJSON
+	}
+	return json.Unmarshal(data, target)
+}
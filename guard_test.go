@@ -0,0 +1,54 @@
+package gojtp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// recorder records if json.Unmarshal was called on it.
+type recorder struct {
+	called bool
+}
+
+func (r *recorder) UnmarshalJSON(data []byte) error {
+	r.called = true
+	return nil
+}
+
+func TestGuard(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(5))
+	v := verifier.(Verify)
+
+	var target struct {
+		Name string `json:"name"`
+		Tags []int  `json:"tags"`
+	}
+	if err := v.Guard([]byte(`{"name": "gojtp", "tags": [1, 2]}`),
+		&target); err != nil {
+		t.Fatalf("Expected a nil error Got - %v", err)
+	}
+	if target.Name != "gojtp" || len(target.Tags) != 2 {
+		t.Errorf("Expected the decoded target Got %+v", target)
+	}
+
+	var r recorder
+	err := v.Guard([]byte(`{"name": "too long"}`), &r)
+	checkResult(t, false, false, fmt.Errorf(
+		"jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[8]"), err)
+	err = v.Guard([]byte(`{"name": `), &r)
+	checkResult(t, false, false, ErrInvalidJSON, err)
+	if r.called {
+		t.Errorf("Expected json.Unmarshal not to be called")
+	}
+
+	// the decoding errors are returned as is
+	var n int
+	err = v.Guard([]byte(`"a"`), &n)
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Errorf("Expected a json.UnmarshalTypeError Got - %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package gojtp
+
+// Histogram counts the values of a JSON by type, the containers
+// included, e.g. {"a": [1, "b"]} has one object, one array, one
+// number and one string.
+type Histogram struct {
+	Objects  int
+	Arrays   int
+	Strings  int
+	Numbers  int
+	Booleans int
+	Nulls    int
+}
+
+// histogramHandler counts the values of a walk in a Histogram,
+// the keys are not values.
+type histogramHandler struct {
+	h Histogram
+}
+
+func (h *histogramHandler) begin(c byte, i int) error {
+	if c == '{' {
+		h.h.Objects++
+	} else {
+		h.h.Arrays++
+	}
+	return nil
+}
+
+func (h *histogramHandler) end(c byte, i int) error {
+	return nil
+}
+
+func (h *histogramHandler) key(data []byte, startIndex, endIndex int) error {
+	return nil
+}
+
+func (h *histogramHandler) value(data []byte, startIndex, endIndex int) error {
+	switch data[startIndex] {
+	case '"':
+		h.h.Strings++
+	case 't', 'f':
+		h.h.Booleans++
+	case 'n':
+		h.h.Nulls++
+	default:
+		h.h.Numbers++
+	}
+	return nil
+}
+
+// TypeHistogram verifies the json as VerifyBytes does and returns the
+// number of values of each type it holds, e.g. to profile the data.
+// When the json fails, the Histogram counts the values walked until
+// the failure.
+func (v Verify) TypeHistogram(json []byte) (Histogram, bool, error) {
+	h := &histogramHandler{}
+	s := state{verifier: v, h: h}
+	ok, err := s.verify(json)
+	return h.h, ok, err
+}
@@ -0,0 +1,46 @@
+package gojtp
+
+import "testing"
+
+func TestTypeHistogram(t *testing.T) {
+	t.Parallel()
+	h, ok, err := Verify{}.TypeHistogram(_getTestJSONBytes())
+	checkResult(t, true, ok, nil, err)
+	expected := Histogram{Objects: 9, Arrays: 6, Strings: 21, Numbers: 4}
+	if h != expected {
+		t.Errorf("Expected histogram %+v Got %+v", expected, h)
+	}
+
+	scenarios := []struct {
+		name string
+		json string
+		h    Histogram
+		ok   bool
+	}{
+		{
+			name: "every type",
+			json: `{"a": [1, -2.5, "s", true, false, null], "b": {}, "c": []}`,
+			h: Histogram{Objects: 2, Arrays: 2, Strings: 1, Numbers: 2,
+				Booleans: 2, Nulls: 1},
+			ok: true,
+		},
+		{name: "scalar", json: `"top"`, h: Histogram{Strings: 1}, ok: true},
+		{
+			name: "malformed",
+			json: `[1, {"a": null}, tru]`,
+			h:    Histogram{Objects: 1, Arrays: 1, Numbers: 1, Nulls: 1},
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			h, ok, err := Verify{}.TypeHistogram([]byte(tc.json))
+			if ok != tc.ok || (err == nil) != tc.ok {
+				t.Errorf("Expected validation %v Got %v %v", tc.ok, ok, err)
+			}
+			if h != tc.h {
+				t.Errorf("Expected histogram %+v Got %+v", tc.h, h)
+			}
+		})
+	}
+}
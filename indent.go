@@ -0,0 +1,77 @@
+package gojtp
+
+// indentHandler writes the events of a walk as an indented JSON.
+type indentHandler struct {
+	indent string
+	out    []byte
+	// open has the open containers, with their values or keys count.
+	open []canonicalFrame
+}
+
+// newline appends a newline and the indentation of the depth.
+func (h *indentHandler) newline(depth int) {
+	h.out = append(h.out, '\n')
+	for ; depth > 0; depth-- {
+		h.out = append(h.out, h.indent...)
+	}
+}
+
+// element starts a value of an array or a key of an object on its
+// own line, a value of an object follows its key.
+func (h *indentHandler) element(key bool) {
+	k := len(h.open) - 1
+	if k < 0 || h.open[k].object != key {
+		return
+	}
+	if h.open[k].count++; h.open[k].count > 1 {
+		h.out = append(h.out, ',')
+	}
+	h.newline(len(h.open))
+}
+
+func (h *indentHandler) begin(c byte, i int) error {
+	h.element(false)
+	h.out = append(h.out, c)
+	h.open = append(h.open, canonicalFrame{object: c == '{'})
+	return nil
+}
+
+func (h *indentHandler) end(c byte, i int) error {
+	f := h.open[len(h.open)-1]
+	h.open = h.open[:len(h.open)-1]
+	if f.count > 0 {
+		h.newline(len(h.open))
+	}
+	h.out = append(h.out, c)
+	return nil
+}
+
+func (h *indentHandler) key(data []byte, startIndex, endIndex int) error {
+	h.element(true)
+	h.out = append(h.out, data[startIndex:endIndex]...)
+	h.out = append(h.out, ':', ' ')
+	return nil
+}
+
+func (h *indentHandler) value(data []byte, startIndex, endIndex int) error {
+	h.element(false)
+	h.out = append(h.out, data[startIndex:endIndex]...)
+	return nil
+}
+
+// VerifyAndIndent returns, when the json is valid and is JSON
+// THREAT Protection Safe, its indented form, e.g. to read a failed
+// payload when debugging: each array value and object member on its
+// own line, indented with one copy of indent per level, a space after
+// each colon and the empty containers kept as {} and [], as
+// encoding/json Indent writes it without prefix. The strings and the
+// numbers are copied as written.
+func (v Verify) VerifyAndIndent(json []byte, indent string) ([]byte,
+	bool, error) {
+	h := &indentHandler{indent: indent}
+	s := state{verifier: v, h: h}
+	if ok, err := s.verify(json); !ok || err != nil {
+		return nil, ok, err
+	}
+	return h.out, true, nil
+}
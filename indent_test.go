@@ -0,0 +1,48 @@
+package gojtp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestVerifyAndIndent(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(8))
+	v := verifier.(Verify)
+	scenarios := []string{
+		`{"a":1,"b":[true,null,{"c":"dé"}],"e":{},"f":[]}`,
+		` [ 1.50e+3 , -0 , "x\ty" , [ [ ] , { } ] ] `,
+		`{"nested":{"deeper":{"list":[1,[2,[3]]]}},"s":"\"q\""}`,
+		`"top"`,
+		`42`,
+		`[]`,
+	}
+	for _, src := range scenarios {
+		for _, indent := range []string{"  ", "\t"} {
+			t.Run(src, func(t *testing.T) {
+				out, ok, err := v.VerifyAndIndent([]byte(src), indent)
+				checkResult(t, true, ok, nil, err)
+				var expected bytes.Buffer
+				if err := json.Indent(&expected, bytes.TrimSpace(
+					[]byte(src)), "", indent); err != nil {
+					t.Fatal(err)
+				}
+				if string(out) != expected.String() {
+					t.Errorf("Expected %q Got %q", expected.String(), out)
+				}
+			})
+		}
+	}
+
+	t.Run("unsafe", func(t *testing.T) {
+		out, ok, err := v.VerifyAndIndent([]byte(`["too long string"]`), "  ")
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[15]"),
+			err)
+		if out != nil {
+			t.Errorf("Expected no output Got %q", out)
+		}
+	})
+}
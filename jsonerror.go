@@ -0,0 +1,65 @@
+package gojtp
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// errorDocument is the JSON form of a verification error.
+type errorDocument struct {
+	Kind    string `json:"kind"`
+	Max     int    `json:"max,omitempty"`
+	Found   int    `json:"found,omitempty"`
+	Offset  *int   `json:"offset,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// VerifyBytesJSONError verifies the json as VerifyBytes does and
+// returns nil and true if it passes, else the error as a JSON
+// document and false, e.g. for the body of an HTTP 400 response:
+//
+//	{"kind":"maxStringValueLengthReached","max":25,"found":47,
+//	"offset":120,"path":"/a/b","message":"jtp.maxStringValue..."}
+//
+// kind is the Kind of a *LimitError, the Reason of a *MalformedError,
+// numberRejected, stringRejected or keyRejected for a *RejectedError
+// and otherwise the name following jtp. in the error, like
+// MalformedJSON. max and found are set for a *LimitError, offset
+// when the error has one and path for a *LimitError with a Path.
+// message is the error text.
+func (v Verify) VerifyBytesJSONError(json []byte) ([]byte, bool) {
+	ok, err := v.VerifyBytes(json)
+	if ok && err == nil {
+		return nil, true
+	}
+	if err == nil {
+		err = ErrInvalidJSON
+	}
+	return errorJSON(err), false
+}
+
+// errorJSON returns the JSON document of err.
+func errorJSON(err error) []byte {
+	doc := errorDocument{Message: err.Error()}
+	var le *LimitError
+	var me *MalformedError
+	var re *RejectedError
+	switch {
+	case errors.As(err, &le):
+		doc.Kind, doc.Max, doc.Found = le.Kind, le.Max, le.Found
+		doc.Offset, doc.Path = &le.Offset, le.Path
+	case errors.As(err, &me):
+		doc.Kind, doc.Offset = me.Reason, &me.Offset
+	case errors.As(err, &re):
+		doc.Kind, doc.Offset = re.Kind+"Rejected", &re.Offset
+	default:
+		doc.Kind = strings.TrimPrefix(doc.Message, "jtp.")
+		if i := strings.IndexByte(doc.Kind, '.'); i >= 0 {
+			doc.Kind = doc.Kind[:i]
+		}
+	}
+	b, _ := json.Marshal(doc)
+	return b
+}
@@ -0,0 +1,48 @@
+package gojtp
+
+import "testing"
+
+func TestVerifyBytesJSONError(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(5))
+	v := verifier.(Verify)
+	scenarios := []struct {
+		name   string
+		json   string
+		expOk  bool
+		expDoc string
+	}{
+		{
+			name:  "valid",
+			json:  `{"a": {"b": "01234"}}`,
+			expOk: true,
+		},
+		{
+			name:  "limit",
+			json:  `{"a": {"b": "0123456789"}}`,
+			expOk: false,
+			expDoc: `{"kind":"maxStringValueLengthReached","max":5,` +
+				`"found":10,"offset":12,"path":"/a/b","message":` +
+				`"jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[10]"}`,
+		},
+		{
+			name:   "malformed",
+			json:   `{"a":`,
+			expOk:  false,
+			expDoc: `{"kind":"MalformedJSON","message":"jtp.MalformedJSON"}`,
+		},
+	}
+	for _, tc := range scenarios {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			doc, ok := v.VerifyBytesJSONError([]byte(tc.json))
+			if ok != tc.expOk {
+				t.Fatalf("expected ok %v got %v", tc.expOk, ok)
+			}
+			if string(doc) != tc.expDoc {
+				t.Errorf("expected %s got %s", tc.expDoc, doc)
+			}
+		})
+	}
+}
@@ -4,8 +4,15 @@
 package gojtp
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"unicode"
+	"unicode/utf16"
 	"unicode/utf8"
 )
 
@@ -17,18 +24,82 @@ type (
 const (
 	objectKeyValueLength string = "maxKeyLengthReached"
 	stringValueLength    string = "maxStringValueLengthReached"
+	stringValueWeight    string = "maxStringWeightReached"
+	topLevelKeyLength    string = "maxTopLevelKeyLengthReached"
+	arrayElementCount    string = "maxArrayElementCountReached"
+	containerDepth       string = "maxContainerDepthReached"
+	objectEntryCount     string = "maxObjectEntryCountReached"
+	containerCount       string = "maxContainerCountReached"
+	consecutiveEscapes   string = "maxConsecutiveEscapesReached"
+	decodedMemory        string = "maxDecodedMemoryEstimateReached"
+	documentSize         string = "maxDocumentSizeReached"
+	structuralStates     string = "maxStructuralStatesReached"
+	transitions          string = "maxStructureTransitionsReached"
+	consecutiveOpens     string = "maxConsecutiveOpensReached"
+	stringUTF16Units     string = "maxStringUTF16UnitsReached"
+	keyUTF16Units        string = "maxKeyUTF16UnitsReached"
+	arrayRank            string = "maxArrayRankReached"
+	flattenedElements    string = "maxFlattenedArrayElementsReached"
+	tableCells           string = "maxTableCellsReached"
+	totalKeyBytes        string = "maxTotalKeyBytesReached"
+	arrayUnitBudget      string = "arrayUnitBudgetExceeded"
+	collectedStrings     string = "maxCollectedStringsReached"
+	flattenedLeaves      string = "maxFlattenedLeavesReached"
+	subtreeEntries       string = "maxSubtreeEntriesReached"
+	identicalRepeats     string = "maxIdenticalValueRepeatsReached"
+	stringScripts        string = "tooManyScriptsInString"
+	combiningMarks       string = "tooManyCombiningMarks"
+	colonWhitespace      string = "maxColonWhitespaceReached"
+	totalObjects         string = "maxTotalObjectsReached"
+	keyByteLength        string = "maxKeyByteLengthReached"
+	leadingScalars       string = "tooManyLeadingScalars"
+	exponentDigits       string = "maxExponentDigitsReached"
+	arrayLiteralBytes    string = "maxArrayLiteralBytesReached"
+	totalEscapes         string = "maxTotalEscapesReached"
+	cumulativeEntries    string = "maxCumulativeEntriesReached"
+	cumulativeBytes      string = "maxCumulativeBytesReached"
+	streamTopLevelKeys   string = "streamTooManyDistinctTopLevelKeys"
+	emptyValues          string = "maxEmptyValuesReached"
+	numberDigits         string = "maxNumberDigitsReached"
+	floatCount           string = "maxFloatCountReached"
+	totalNumberBytes     string = "maxTotalNumberBytesReached"
+	trueCount            string = "maxTrueCountReached"
+	scanBudget           string = "scanBudgetExceeded"
+	tokenGap             string = "maxTokenGapReached"
+	tokenBytes           string = "tokenTooLong"
+	embeddedDepth        string = "maxEmbeddedJSONDepthReached"
+	canonicalSize        string = "maxCanonicalSizeReached"
+)
+
+// reasons of a MalformedError.
+const (
+	emptyArrayElement string = "emptyArrayElement"
+	trailingComma     string = "trailingComma"
+	emptyObjectValue  string = "emptyObjectValue"
+	unexpectedByte    string = "unexpectedByte"
 )
 
 var (
 	// ErrInvalidJSON denotes JSON is Malformed
 	ErrInvalidJSON = errors.New("jtp.MalformedJSON")
+	// ErrEmptyInput denotes JSON is empty or contains only whitespace
+	ErrEmptyInput = errors.New("jtp.EmptyInput")
+	// ErrBareTopLevelValue denotes JSON top level is not an object or array
+	ErrBareTopLevelValue = errors.New("jtp.bareTopLevelValue")
+	// ErrEmptyTopLevelContainer denotes JSON top level is {} or []
+	ErrEmptyTopLevelContainer = errors.New("jtp.emptyTopLevelContainer")
 )
 
 // Verifier is the interface that wraps the basic
-// Verify, VerifyBytes and VerifyString methods.
+// Verify, VerifyBytes, VerifyString and VerifyReader methods.
+//
+// VerifyReader was added to the interface so the decorators,
+// like Combine and WithTimeout, handle every input type; a
+// Verifier implemented outside of this package must add it.
 type Verifier interface {
 	VerifyBytes([]byte) (bool, error)
 	VerifyString(string) (bool, error)
+	VerifyReader(io.Reader) (bool, error)
 }
 
 // Verify Configuration Parameters.
@@ -49,6 +120,49 @@ type Verify struct {
 	// Specifies the maximum number of elements allowed in an array.
 	MaxArrayElementCount   int
 	arrayEntryCountEnabled bool
+	// Specifies the maximum number of elements of the arrays directly
+	// under some keys, overriding MaxArrayElementCount, zero for none.
+	ArrayLimitOverrides map[string]int
+	// Specifies the maximum units allowed in an array, where an
+	// object element counts ArrayObjectWeight and any other one.
+	ArrayUnitBudget        int
+	ArrayObjectWeight      int
+	arrayUnitBudgetEnabled bool
+	// Specifies the maximum number of scalars allowed before
+	// the first container element of an array.
+	MaxLeadingScalars     int
+	leadingScalarsEnabled bool
+	// Specifies the maximum number of bytes of an array literal,
+	// from its [ to its matching ].
+	MaxArrayLiteralBytes     int
+	arrayLiteralBytesEnabled bool
+	// Specifies if all the elements of an array must have the same
+	// type, and if null elements are accepted among them.
+	HomogeneousArrays        bool
+	NullsInHomogeneousArrays bool
+	// Specifies if the objects of an array must have the same keys.
+	UniformObjectKeysInArrays bool
+	// Specifies if the null values are rejected.
+	DisallowNull bool
+	// Specifies the maximum number of true values in the JSON.
+	MaxTrueCount     int
+	trueCountEnabled bool
+	// Specifies the maximum number of directly nested arrays,
+	// like the rank of a matrix.
+	MaxArrayRank     int
+	arrayRankEnabled bool
+	// Specifies the maximum number of leaf elements of directly
+	// nested arrays together.
+	MaxFlattenedArrayElements int
+	flattenedElementsEnabled  bool
+	// Specifies the maximum number of leaves mapped by VerifyFlatten,
+	// MaxFlattenedLeaves if not enabled.
+	FlattenedLeaves        int
+	flattenedLeavesEnabled bool
+	// Specifies the maximum number of cells, rows times columns,
+	// of an array of objects.
+	MaxTableCells     int
+	tableCellsEnabled bool
 	// Specifies the maximum allowed containment depth,
 	// where the containers are objects or arrays.
 	JSONContainerDepth        int
@@ -57,13 +171,304 @@ type Verify struct {
 	// Specifies the maximum number of entries allowed in an object
 	ObjectEntryCount        int
 	objectEntryCountEnabled bool
+	// Specifies the maximum number of entries of the objects directly
+	// under some keys, overriding ObjectEntryCount, zero for none.
+	ObjectEntryLimitOverrides map[string]int
+	// Specifies the maximum number of null, "", {} and [] values
+	// of the object entries and array elements of the JSON.
+	MaxEmptyValues     int
+	emptyValuesEnabled bool
+	// Specifies the maximum number of entries allowed in an object
+	// and all the objects nested under it combined.
+	MaxSubtreeEntries     int
+	subtreeEntriesEnabled bool
+	// Specifies the maximum number of object entries and of bytes
+	// of all the JSON fed to a StreamValidator combined.
+	CumulativeMaxEntries     int
+	cumulativeEntriesEnabled bool
+	CumulativeMaxBytes       int
+	cumulativeBytesEnabled   bool
+	// Specifies the maximum number of distinct top level keys of
+	// all the JSON fed to a StreamValidator combined.
+	StreamMaxDistinctTopLevelKeys int
+	streamTopLevelKeysEnabled     bool
 	// Specifies the maximum string length
 	// allowed for a property name within an object.
 	ObjectKeyLength        int
 	objectKeyLengthEnabled bool
+	// Specifies the maximum length in bytes allowed
+	// for a property name within an object.
+	ObjectKeyByteLength  int
+	keyByteLengthEnabled bool
+	// Specifies the maximum byte length of all the property
+	// names of the JSON combined.
+	MaxTotalKeyBytes     int
+	totalKeyBytesEnabled bool
+	// Specifies the maximum string length allowed for a property
+	// name within the top-level object only.
+	TopLevelKeyLength        int
+	topLevelKeyLengthEnabled bool
 	// Specifies the maximum length allowed for a string value.
 	StringValueLen   int
 	stringLenEnabled bool
+	// Specifies the keys whose string value doesn't have
+	// the StringValueLen limit.
+	RelaxedStringLengthKeys []string
+	// Specifies the keys whose string value must be an http
+	// or https URL, or only https if RequireHTTPSURLs.
+	URLKeys          []string
+	RequireHTTPSURLs bool
+	// stringFormats are the formats of the string values under a key.
+	stringFormats []formatRule
+	// epochRanges are the ranges of the integers under a key.
+	epochRanges []epochRule
+	// Specifies the keys the top level object must have.
+	RequiredTopLevelKeys []string
+	// Specifies the only keys the top level object can have.
+	AllowedTopLevelKeys        []string
+	allowedTopLevelKeysEnabled bool
+	// Specifies the maximum ratio of the length of an object value
+	// to the length of its key.
+	MaxKeyValueLengthRatio float64
+	keyValueRatioEnabled   bool
+	// Specifies the key and string value length limits
+	// of some depth levels, overriding the limits above.
+	DepthLimits map[int]Limits
+	// versionKey is the top level key holding the version of the
+	// document and versionedLimits the limits of each version.
+	versionKey      string
+	versionedLimits map[string]Limits
+	// Specifies the maximum weight allowed for a string value,
+	// where ASCII counts as one and multibyte runes as their byte length.
+	StringValueWeight   int
+	stringWeightEnabled bool
+	// Specifies if string values containing U+FFFD are rejected.
+	RejectReplacementChar bool
+	// Specifies if strings containing an escaped U+0000 are rejected.
+	RejectNullCharInStrings bool
+	// Specifies if strings containing a Unicode noncharacter,
+	// like U+FFFE, are rejected.
+	RejectNoncharacters bool
+	// Specifies if string values containing a zero width character,
+	// like U+200B, are rejected.
+	RejectZeroWidthChars bool
+	// Specifies if strings containing a bidirectional control
+	// character, like U+202E, are rejected.
+	RejectBidiControls bool
+	// Specifies if strings containing DEL or a C1 control
+	// character are rejected.
+	RejectExtendedControls bool
+	// Specifies how the strings may write a forward slash.
+	ForwardSlashPolicy SlashPolicy
+	// Specifies if the property names must be identifiers,
+	// matching ^[A-Za-z_][A-Za-z0-9_]*$.
+	KeysMustBeIdentifiers bool
+	// Specifies if the property names made only of digits,
+	// matching ^[0-9]+$, are rejected.
+	RejectNumericKeys bool
+	// Specifies if the numeric property names of an object coercing
+	// to the same JavaScript number are rejected.
+	RejectCoercibleKeyCollisions bool
+	// Specifies the maximum run of adjacent escape sequences
+	// allowed in a string.
+	MaxConsecutiveEscapes     int
+	consecutiveEscapesEnabled bool
+	// Specifies the maximum number of distinct scripts, like Latin
+	// and Cyrillic, mixed in a string value.
+	MaxScriptsPerString int
+	scriptsEnabled      bool
+	// Specifies the maximum run of combining marks allowed
+	// in a string value.
+	MaxCombiningMarks     int
+	combiningMarksEnabled bool
+	// Specifies the maximum number of escape sequences in all the
+	// string keys and values of a JSON.
+	MaxTotalEscapes     int
+	totalEscapesEnabled bool
+	// Specifies if the top level value must be an object or array.
+	RequireStructuredTopLevel bool
+	// Specifies if a top level object or array must not be empty.
+	TopLevelMustBeNonEmpty bool
+	// Specifies if the first and last bytes are checked before
+	// the walk.
+	QuickReject bool
+	// Specifies the maximum estimated memory in bytes of the
+	// decoded JSON, computed with DecodedMemoryCosts.
+	MaxDecodedMemoryEstimate int
+	DecodedMemoryCosts       MemoryCosts
+	decodedMemoryEnabled     bool
+	// Specifies the maximum number of bytes scanned, counting the
+	// bytes walked and the bytes rescanned by the checks.
+	MaxScanBudget     int
+	scanBudgetEnabled bool
+	// Specifies the maximum number of bytes between two structural
+	// tokens, { } [ ] : and comma.
+	MaxTokenGap     int
+	tokenGapEnabled bool
+	// Specifies the maximum number of raw bytes of a single string,
+	// key, number or literal token.
+	MaxTokenBytes     int
+	tokenBytesEnabled bool
+	// Specifies the maximum size in bytes of the JCS form of the JSON.
+	MaxCanonicalSize     int
+	canonicalSizeEnabled bool
+	// Specifies the maximum nesting of JSON embedded in string values.
+	MaxEmbeddedJSONDepth int
+	embeddedJSONEnabled  bool
+	// Specifies if the string values looking like a JSON are rejected.
+	FlagEmbeddedJSON bool
+	// Specifies if numbers that do not round-trip through
+	// float64 are rejected.
+	RejectLossyNumbers bool
+	// Specifies if non zero numbers that underflow to zero
+	// as float64 are rejected.
+	RejectUnderflowNumbers bool
+	// Specifies if numbers that overflow float64 are rejected.
+	RejectOverflowNumbers bool
+	// Specifies if the negative zero numbers, like -0, are rejected.
+	RejectNegativeZero bool
+	// Specifies if integers out of the int64 range are rejected.
+	Int64RangeNumbers bool
+	// Specifies if any byte outside of ASCII is rejected.
+	ASCIIOnly bool
+	// Specifies if numbers with an exponent part are rejected.
+	PlainDecimalNumbersOnly bool
+	// Specifies if numbers not in their canonical form, like 1.50,
+	// are rejected.
+	CanonicalNumbers bool
+	// Specifies the maximum number of digits in the exponent
+	// part of a number.
+	MaxExponentDigits     int
+	exponentDigitsEnabled bool
+	// Specifies the maximum number of digits of a number, in its
+	// integer, fraction and exponent parts combined.
+	MaxNumberDigits     int
+	numberDigitsEnabled bool
+	// Specifies the maximum number of significant digits of a bare
+	// number, the larger numbers must be written as strings.
+	MaxBareDigits     int
+	bareDigitsEnabled bool
+	// Specifies the maximum number of numbers with a fraction or an
+	// exponent part in the JSON.
+	MaxFloatCount     int
+	floatCountEnabled bool
+	// Specifies the maximum number of bytes of all the number tokens
+	// of the JSON combined.
+	MaxTotalNumberBytes     int
+	totalNumberBytesEnabled bool
+	// Specifies if raw newlines and tabs are accepted
+	// within string values.
+	TolerateRawControlInStrings bool
+	// Specifies if the string values and the numbers are only scanned
+	// to their end, without validating their grammar.
+	SkipStringValidation bool
+	SkipNumberValidation bool
+	// Specifies the maximum number of occurrences of a same scalar
+	// value, among the values up to TrackedValueLength bytes.
+	MaxIdenticalValueRepeats int
+	TrackedValueLength       int
+	identicalRepeatsEnabled  bool
+	// Specifies the maximum fraction of the scalar values a same
+	// value can be, among the values up to TrackedValueLength bytes.
+	MaxValueRepetitionFraction float64
+	valueFractionEnabled       bool
+	// Specifies the maximum number of occurrences of a same property
+	// name, among the names up to TrackedKeyLength bytes.
+	MaxGlobalKeyRepeats int
+	TrackedKeyLength    int
+	keyRepeatsEnabled   bool
+	// Specifies the maximum entropy in bits per byte of the string
+	// values of at least MinEntropyStringLength bytes.
+	MaxStringValueEntropy  float64
+	MinEntropyStringLength int
+	entropyEnabled         bool
+	// Specifies the maximum number of identical consecutive
+	// characters in a string value.
+	MaxCharRunInString int
+	charRunEnabled     bool
+	// Specifies the maximum number of containers (objects and arrays)
+	// allowed in the whole JSON.
+	MaxContainerCount     int
+	containerCountEnabled bool
+	// Specifies the maximum number of objects allowed
+	// in the whole JSON.
+	MaxTotalObjects     int
+	totalObjectsEnabled bool
+	// Specifies the minimum average number of entries of the objects
+	// of the JSON.
+	MinAverageObjectFill float64
+	objectFillEnabled    bool
+	// numberValidator if set validates each number token.
+	numberValidator func(token []byte) error
+	// stringValidator if set validates each string key and value.
+	stringValidator func(value []byte, isKey bool) error
+	// stringValueCharset reports if a rune is allowed in string values.
+	stringValueCharset func(rune) bool
+	// approachFn is called when a limit reaches approachFraction
+	// of its max.
+	approachFraction float64
+	approachFn       func(kind string, found, max int)
+	// warnings are the checks only reported by VerifyBytesAll.
+	warnings []Verify
+	// typeSchema maps the JSON Pointers to the expected value types.
+	typeSchema map[string]Type
+	// discriminators are the keys required in the objects at a path.
+	discriminators []discriminator
+	// uniformValues are the values required in the objects under a key.
+	uniformValues []uniformRule
+	// sortedArrays are the orders required of the arrays under a key.
+	sortedArrays []sortRule
+	// allowlist holds the documents VerifyBytes passes without a walk.
+	allowlist map[string]struct{}
+	// Specifies the keys whose value subtree doesn't count
+	// toward the JSONContainerDepth.
+	DepthExemptKeys []string
+	// Specifies the maximum number of whitespace bytes allowed
+	// around each colon.
+	MaxColonWhitespace     int
+	colonWhitespaceEnabled bool
+	// Specifies the maximum size in bytes of the JSON document.
+	MaxDocumentSize     int
+	documentSizeEnabled bool
+	// Specifies the maximum number of distinct
+	// (depth, container type) states in the JSON.
+	MaxStructuralStates     int
+	structuralStatesEnabled bool
+	// Specifies the maximum number of containers entered within
+	// a container of the other type in the JSON.
+	MaxStructureTransitions int
+	transitionsEnabled      bool
+	// Specifies the maximum number of containers opened in a row,
+	// without a scalar value or a container closed in between.
+	MaxConsecutiveOpens     int
+	consecutiveOpensEnabled bool
+	// Specifies the maximum bytes of a container per element or entry.
+	MaxBytesPerChild     int
+	bytesPerChildEnabled bool
+	// Specifies the maximum length in UTF-16 code units
+	// allowed for a string value.
+	StringValueUTF16Units   int
+	stringUTF16UnitsEnabled bool
+	// Specifies the maximum length in UTF-16 code units
+	// allowed for a property name within an object.
+	ObjectKeyUTF16Units  int
+	keyUTF16UnitsEnabled bool
+}
+
+// MemoryCosts are the estimated bytes a decoded JSON consumes per
+// container child, on top of the bytes of its strings.
+type MemoryCosts struct {
+	// ObjectEntry is the cost of an object entry (map overhead).
+	ObjectEntry int
+	// ArrayElement is the cost of an array element (slice overhead).
+	ArrayElement int
+}
+
+// DefaultMemoryCosts approximates the cost of decoding a JSON
+// into Go map[string]interface{} and []interface{} values.
+var DefaultMemoryCosts = MemoryCosts{
+	ObjectEntry:  48,
+	ArrayElement: 16,
 }
 
 // New creates and return an Verifier with passed Option Parameters,
@@ -83,6 +488,8 @@ func New(opt ...Option) (Verifier, error) {
 // WithMaxArrayElementCount Option
 // Specifies the maximum number of entries (
 // comma delimited values)  allowed in an array.
+// An array with exactly l elements is allowed, one with l+1 fails
+// reporting Found-[l+1], same as the object entry count.
 // zero value disable the check.
 func WithMaxArrayElementCount(l int) Option {
 	return func(verifier *Verify) error {
@@ -99,9 +506,71 @@ func WithMaxArrayElementCount(l int) Option {
 	}
 }
 
+// WithArrayLimitOverride Option
+// Specifies the maximum number of elements of the arrays directly
+// under the key, overriding WithMaxArrayElementCount for them, e.g.
+// for a legitimately large targets array while all the other arrays
+// must stay small. zero value disable the check for these arrays.
+func WithArrayLimitOverride(key string, l int) Option {
+	return func(verifier *Verify) error {
+		if l < 0 {
+			return fmt.Errorf("jtp: array limit override cannot be"+
+				" negative %d", l)
+		}
+		overrides := make(map[string]int, len(verifier.ArrayLimitOverrides)+1)
+		for k, n := range verifier.ArrayLimitOverrides {
+			overrides[k] = n
+		}
+		overrides[key] = l
+		verifier.ArrayLimitOverrides = overrides
+		return nil
+	}
+}
+
+// WithObjectEntryLimitForKey Option
+// Specifies the maximum number of entries of the objects directly
+// under the key, overriding WithMaxObjectEntryCount for them, e.g. up
+// to 20 entries for the objects under request and 5 elsewhere.
+// zero value disable the check for these objects.
+func WithObjectEntryLimitForKey(key string, l int) Option {
+	return func(verifier *Verify) error {
+		if l < 0 {
+			return fmt.Errorf("jtp: object entry limit cannot be"+
+				" negative %d", l)
+		}
+		overrides := make(map[string]int,
+			len(verifier.ObjectEntryLimitOverrides)+1)
+		for k, n := range verifier.ObjectEntryLimitOverrides {
+			overrides[k] = n
+		}
+		overrides[key] = l
+		verifier.ObjectEntryLimitOverrides = overrides
+		return nil
+	}
+}
+
+// keyLimit returns the limit of overrides for the containers
+// directly under the key of the valid string span, if overridden.
+func keyLimit(overrides map[string]int, data []byte, startIndex,
+	endIndex int) (max int, overridden bool) {
+	for key, l := range overrides {
+		if stringEquals(data, startIndex, endIndex, key) {
+			return l, true
+		}
+	}
+	return 0, false
+}
+
 // WithMaxContainerDepth Option
 // Specifies the maximum allowed nested containers depth, within a JSON
 // where the containers are objects or arrays.
+// Objects and arrays are counted together, so [{"a": [1]}] has a
+// depth of 3, the error reports the type of the container exceeding
+// it, like jtp.maxContainerDepthReached.Type-[array].
+// The depth is the real nesting level: a top-level scalar is at
+// depth 0, the top-level container at depth 1 and its children
+// containers at depth 2, so with a max of 2 the Found of the error
+// is 3, the level of the first container beyond the limit.
 // zero value disable the checks
 func WithMaxContainerDepth(l int) Option {
 	return func(verifier *Verify) error {
@@ -118,6 +587,154 @@ func WithMaxContainerDepth(l int) Option {
 	}
 }
 
+// WithArrayUnitBudget Option
+// Specifies the maximum units allowed in an array, where an object
+// element counts objectWeight units and any other element one unit,
+// modeling the heterogeneous cost of the elements.
+// zero value disable the checks
+func WithArrayUnitBudget(l int, objectWeight int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: array unit budget cannot be"+
+				" negative %d", l)
+		}
+		if objectWeight < 1 {
+			return fmt.Errorf("jtp: array object weight must be"+
+				" positive %d", objectWeight)
+		}
+		verifier.ArrayUnitBudget = l
+		verifier.ArrayObjectWeight = objectWeight
+		verifier.arrayUnitBudgetEnabled = true
+		return nil
+	}
+}
+
+// WithMaxLeadingScalarsInArray Option
+// Specifies the maximum number of scalar elements allowed before the
+// first container (object or array) element of an array, like the
+// header of an heterogeneous array. Arrays without containers are
+// not affected.
+// zero value disable the checks
+func WithMaxLeadingScalarsInArray(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max leading scalars cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxLeadingScalars = l
+		verifier.leadingScalarsEnabled = true
+		return nil
+	}
+}
+
+// WithMaxArrayLiteralBytes Option
+// Specifies the maximum number of bytes of any array literal, from
+// its [ to its matching ], whitespace included, which catches the
+// arrays of a few but gigantic elements.
+// zero value disable the checks
+func WithMaxArrayLiteralBytes(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max array literal bytes cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxArrayLiteralBytes = l
+		verifier.arrayLiteralBytesEnabled = true
+		return nil
+	}
+}
+
+// WithMaxArrayRank Option
+// Specifies the maximum number of arrays directly nested in one
+// another, like the rank of a matrix, [[1, 2], [3, 4]] has rank 2.
+// An object in between starts a new run, so objects are not affected.
+// zero value disable the checks
+func WithMaxArrayRank(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max array rank cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxArrayRank = l
+		verifier.arrayRankEnabled = true
+		return nil
+	}
+}
+
+// WithMaxFlattenedArrayElements Option
+// Specifies the maximum number of leaf elements, the elements other
+// than arrays, of arrays directly nested in one another counted
+// together, as if flattened, [[1, 2, 3], [4, 5, 6]] has 6.
+// An object in between starts a new count, and counts as one leaf.
+// zero value disable the checks
+func WithMaxFlattenedArrayElements(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max flattened array elements cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxFlattenedArrayElements = l
+		verifier.flattenedElementsEnabled = true
+		return nil
+	}
+}
+
+// WithMaxFlattenedLeaves Option
+// Specifies the maximum number of leaves VerifyFlatten maps from a
+// JSON, instead of the default MaxFlattenedLeaves.
+// zero value keeps the default
+func WithMaxFlattenedLeaves(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max flattened leaves cannot be"+
+				" negative %d", l)
+		}
+		verifier.FlattenedLeaves = l
+		verifier.flattenedLeavesEnabled = true
+		return nil
+	}
+}
+
+// WithMaxTableCells Option
+// Specifies the maximum number of cells of a table, an array whose
+// first element is an object, counted as its number of elements,
+// the rows, times the largest entry count of its objects so far, the
+// columns, e.g. a 100 x 100 table has 10000 cells. Use it with
+// WithHomogeneousArrays for all the rows to be objects.
+// zero value disable the checks
+func WithMaxTableCells(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max table cells cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTableCells = l
+		verifier.tableCellsEnabled = true
+		return nil
+	}
+}
+
 // WithMaxObjectKeyLength Option
 // Specifies the maximum number of characters (UTF-8 encoded)
 // allowed for a property(key) name within an object.
@@ -137,6 +754,66 @@ func WithMaxObjectKeyLength(l int) Option {
 	}
 }
 
+// WithMaxObjectKeyByteLength Option
+// Specifies the maximum number of bytes, once decoded in UTF-8,
+// allowed for a property(key) name within an object. It can be
+// combined with the limits counting characters, like
+// WithMaxStringLength, each reporting its own error.
+// zero value disable the checks
+func WithMaxObjectKeyByteLength(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max object key byte length cannot be"+
+				" negative %d", l)
+		}
+		verifier.ObjectKeyByteLength = l
+		verifier.keyByteLengthEnabled = true
+		return nil
+	}
+}
+
+// WithMaxTotalKeyBytes Option
+// Specifies the maximum number of bytes, as encoded in the JSON,
+// of all the property(key) names combined, across the document.
+// zero value disable the checks
+func WithMaxTotalKeyBytes(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max total key bytes cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTotalKeyBytes = l
+		verifier.totalKeyBytesEnabled = true
+		return nil
+	}
+}
+
+// WithMaxTopLevelKeyLength Option
+// Specifies the maximum number of characters (UTF-8 encoded)
+// allowed for a property(key) name within the top-level object,
+// keys of nested objects are not checked by it.
+// zero value disable the checks
+func WithMaxTopLevelKeyLength(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max top level key length cannot be"+
+				" negative %d", l)
+		}
+		verifier.TopLevelKeyLength = l
+		verifier.topLevelKeyLengthEnabled = true
+		return nil
+	}
+}
+
 // WithMaxStringLength Option
 // Specifies the maximum number of characters  (
 // UTF-8 encoded) in a string value.
@@ -156,9 +833,125 @@ func WithMaxStringLength(l int) Option {
 	}
 }
 
+// WithRelaxStringLimitForKeys Option
+// Specifies the keys whose string value is not checked against the
+// maximum string length, e.g. for legitimately long base64 blobs,
+// while keeping a tight limit elsewhere.
+// Only string values directly under the keys are relaxed.
+func WithRelaxStringLimitForKeys(keys ...string) Option {
+	return func(verifier *Verify) error {
+		verifier.RelaxedStringLengthKeys = append(
+			verifier.RelaxedStringLengthKeys, keys...)
+		return nil
+	}
+}
+
+// Limits are the length limits of a depth level,
+// a zero value keeps the global limit.
+type Limits struct {
+	// ObjectKeyLength is the maximum number of characters
+	// of a property name.
+	ObjectKeyLength int
+	// StringValueLen is the maximum number of characters
+	// of a string value.
+	StringValueLen int
+}
+
+// WithDepthLimits Option
+// Specifies the key and string value length limits per depth level,
+// e.g. strict limits on the metadata of the top levels and relaxed
+// ones on the user content deeper. The depth level of a key or value
+// is the number of containers it is nested in, so the keys of the
+// top-level object are at depth 1. The levels without an entry, or
+// a zero limit, use the WithMaxObjectKeyLength and WithMaxStringLength
+// limits.
+func WithDepthLimits(limits map[int]Limits) Option {
+	return func(verifier *Verify) error {
+		depthLimits := make(map[int]Limits,
+			len(verifier.DepthLimits)+len(limits))
+		for depth, l := range verifier.DepthLimits {
+			depthLimits[depth] = l
+		}
+		for depth, l := range limits {
+			if depth < 0 {
+				return fmt.Errorf("jtp: depth level cannot be"+
+					" negative %d", depth)
+			}
+			if l.ObjectKeyLength < 0 || l.StringValueLen < 0 {
+				return fmt.Errorf("jtp: depth level %d limits cannot be"+
+					" negative %+v", depth, l)
+			}
+			depthLimits[depth] = l
+		}
+		verifier.DepthLimits = depthLimits
+		return nil
+	}
+}
+
+// keyLengthLimit returns the key length limit at the depth level.
+func (v *Verify) keyLengthLimit(depth int) (max int, enabled bool) {
+	if l := v.DepthLimits[depth].ObjectKeyLength; l > 0 {
+		return l, true
+	}
+	return v.ObjectKeyLength, v.objectKeyLengthEnabled
+}
+
+// stringLengthLimit returns the string value length limit
+// at the depth level.
+func (v *Verify) stringLengthLimit(depth int) (max int, enabled bool) {
+	if l := v.DepthLimits[depth].StringValueLen; l > 0 {
+		return l, true
+	}
+	return v.StringValueLen, v.stringLenEnabled
+}
+
+// WithMaxStringUTF16Units Option
+// Specifies the maximum length of a string value in UTF-16 code
+// units, as counted by Java or JavaScript, where characters outside
+// the Basic Multilingual Plane count as 2.
+// zero value disable the checks
+func WithMaxStringUTF16Units(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max string UTF-16 units cannot be"+
+				" negative %d", l)
+		}
+		verifier.StringValueUTF16Units = l
+		verifier.stringUTF16UnitsEnabled = true
+		return nil
+	}
+}
+
+// WithMaxKeyUTF16Units Option
+// Specifies the maximum length of a property(key) name within an
+// object in UTF-16 code units, as counted by Java or JavaScript,
+// where characters outside the Basic Multilingual Plane count as 2.
+// zero value disable the checks
+func WithMaxKeyUTF16Units(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max key UTF-16 units cannot be"+
+				" negative %d", l)
+		}
+		verifier.ObjectKeyUTF16Units = l
+		verifier.keyUTF16UnitsEnabled = true
+		return nil
+	}
+}
+
 // WithMaxObjectEntryCount Option
 // Specifies the maximum number of entries
 // (comma delimited string:value pairs) in a single object
+// The count is checked at the key of each entry, before its value,
+// as the array elements are before theirs: the first disallowed
+// entry fails at the offset of its key, and its value is never
+// validated, even malformed.
 // zero value disable the checks
 func WithMaxObjectEntryCount(l int) Option {
 	return func(verifier *Verify) error {
@@ -166,7 +959,7 @@ func WithMaxObjectEntryCount(l int) Option {
 			return nil
 		}
 		if l < 0 {
-			return fmt.Errorf("jtp: max array element count cannot be"+
+			return fmt.Errorf("jtp: max object entry count cannot be"+
 				" negative %d", l)
 		}
 		verifier.ObjectEntryCount = l
@@ -175,227 +968,2884 @@ func WithMaxObjectEntryCount(l int) Option {
 	}
 }
 
-func validateStringLength(data []byte, startIndex, endIndex int,
-	enabled bool, maxAllowed int,
-	strType string) (err error) {
-	str := data[startIndex:endIndex]
-	// JSON exchange in an open ecosystem must be encoded in UTF-8.
-	// https://tools.ietf.org/html/rfc8259#section-8.1
-	l := utf8.RuneCount(str)
-	// -2 for double quote validation skew in length
-	if enabled && l-2 > maxAllowed {
-		err = fmt.Errorf("jtp.%s.Max-[%d]-Allowed.Found-[%d]",
-			strType, maxAllowed, l-2)
-		return
+// WithMaxSubtreeEntries Option
+// Specifies the maximum number of entries allowed in any object
+// subtree, i.e. the entries of an object plus the entries of all
+// the objects nested under it, at any depth.
+// zero value disable the checks
+func WithMaxSubtreeEntries(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max subtree entries cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxSubtreeEntries = l
+		verifier.subtreeEntriesEnabled = true
+		return nil
 	}
-	return
 }
 
-// isValidateString checks if the string is valid or not
-func isValidateString(data []byte, i int) (outi int,
-	ok bool) {
-	for ; i < len(data); i++ {
-		if data[i] < ' ' {
-			return i, false
-		} else if data[i] == '\\' {
-			//
-			i++
-			if i == len(data) {
-				return i, false
-			}
-			switch data[i] {
-			default:
-				return i, false
-			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
-			case 'u':
-				for j := 0; j < 4; j++ {
-					i++
-					if i >= len(data) {
-						return i, false
-					}
-					if !((data[i] >= '0' && data[i] <= '9') ||
-						(data[i] >= 'a' && data[i] <= 'f') ||
-						(data[i] >= 'A' && data[i] <= 'F')) {
-						return i, false
-					}
+// WithCumulativeMaxEntries Option
+// Specifies the maximum number of object entries allowed in all the
+// JSON fed to a StreamValidator combined, like a batch of documents,
+// for VerifyBytes the stream is the single JSON.
+// zero value disable the checks
+func WithCumulativeMaxEntries(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: cumulative max entries cannot be"+
+				" negative %d", l)
+		}
+		verifier.CumulativeMaxEntries = l
+		verifier.cumulativeEntriesEnabled = true
+		return nil
+	}
+}
+
+// WithCumulativeMaxBytes Option
+// Specifies the maximum number of bytes of all the JSON fed to a
+// StreamValidator combined, see WithCumulativeMaxEntries.
+// zero value disable the checks
+func WithCumulativeMaxBytes(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: cumulative max bytes cannot be"+
+				" negative %d", l)
+		}
+		verifier.CumulativeMaxBytes = l
+		verifier.cumulativeBytesEnabled = true
+		return nil
+	}
+}
+
+// WithApproachWarning Option
+// Calls fn, without failing, when a JSON comes within fraction of
+// an enabled limit, e.g. 0.9 for 90%, to tune the limits in
+// production. It covers the container depth, the array element and
+// object entry counts, the key and string value lengths and the
+// document size. fn is called each time a container count or the
+// depth reaches the fraction and for each key or string reaching it,
+// with the kind of the limit like maxArrayElementCountReached.
+// fraction must be in (0, 1].
+func WithApproachWarning(fraction float64,
+	fn func(kind string, found, max int)) Option {
+	return func(verifier *Verify) error {
+		if !(fraction > 0 && fraction <= 1) {
+			return fmt.Errorf("jtp: approach fraction must be in"+
+				" (0, 1] %v", fraction)
+		}
+		verifier.approachFraction = fraction
+		verifier.approachFn = fn
+		return nil
+	}
+}
+
+// WithMaxStringWeight Option
+// Specifies the maximum weight of a string value, where each ASCII
+// character contributes 1 and each multibyte character contributes
+// its UTF-8 encoded byte length, once the escapes are decoded.
+// zero value disable the checks
+func WithMaxStringWeight(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max string weight cannot be"+
+				" negative %d", l)
+		}
+		verifier.StringValueWeight = l
+		verifier.stringWeightEnabled = true
+		return nil
+	}
+}
+
+// WithRejectReplacementChar Option
+// Rejects any string value containing the Unicode replacement
+// character U+FFFD, either raw or as an escape sequence, as it
+// often indicates upstream encoding corruption.
+func WithRejectReplacementChar() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectReplacementChar = true
+		return nil
+	}
+}
+
+// WithRejectNullCharInStrings Option
+// Rejects any string, key or value, containing the escape \u0000,
+// as a NUL breaks many C based consumers. A raw NUL is never valid.
+func WithRejectNullCharInStrings() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectNullCharInStrings = true
+		return nil
+	}
+}
+
+// WithRejectNoncharacters Option
+// Rejects any string, key or value, containing one of the 66 Unicode
+// noncharacters, U+FDD0 to U+FDEF and the last two code points of
+// each plane like U+FFFE, either raw or as an escape sequence. They
+// are valid UTF-8 but not meant for interchange.
+func WithRejectNoncharacters() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectNoncharacters = true
+		return nil
+	}
+}
+
+// WithRejectZeroWidthChars Option
+// Rejects any string value containing a zero width space, non-joiner
+// or joiner, U+200B to U+200D, or a U+FEFF byte order mark, either
+// raw or as an escape sequence, as they hide text from a reader.
+func WithRejectZeroWidthChars() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectZeroWidthChars = true
+		return nil
+	}
+}
+
+// WithRejectExtendedControls Option
+// Rejects any string, key or value, containing the DEL control
+// character U+007F or a C1 control character, U+0080 to U+009F,
+// either raw or as an escape sequence, which JSON allows unescaped
+// but terminals may interpret.
+func WithRejectExtendedControls() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectExtendedControls = true
+		return nil
+	}
+}
+
+// isNoncharacter reports if r is a Unicode noncharacter.
+func isNoncharacter(r rune) bool {
+	return (r >= 0xFDD0 && r <= 0xFDEF) || r&0xFFFE == 0xFFFE
+}
+
+// SlashPolicy specifies how the strings may write a forward slash,
+// raw as / or escaped as \/.
+type SlashPolicy int
+
+const (
+	// SlashAllow accepts both / and \/.
+	SlashAllow SlashPolicy = 0
+	// SlashRequireEscaped rejects a raw /, for the strings embedded
+	// in HTML script tags.
+	SlashRequireEscaped SlashPolicy = 1
+	// SlashForbidEscaped rejects the escape \/.
+	SlashForbidEscaped SlashPolicy = 2
+)
+
+// rejects reports if the policy rejects a forward slash,
+// escaped or raw. Merged policies may reject both.
+func (p SlashPolicy) rejects(escaped bool) bool {
+	if escaped {
+		return p&SlashForbidEscaped != 0
+	}
+	return p&SlashRequireEscaped != 0
+}
+
+// WithForwardSlashPolicy Option
+// Specifies how the strings, keys and values, may write a forward
+// slash, SlashAllow disable the check.
+func WithForwardSlashPolicy(policy SlashPolicy) Option {
+	return func(verifier *Verify) error {
+		if policy < SlashAllow || policy > SlashForbidEscaped {
+			return fmt.Errorf("jtp: unknown forward slash policy %d", policy)
+		}
+		verifier.ForwardSlashPolicy = policy
+		return nil
+	}
+}
+
+// WithKeysMustBeIdentifiers Option
+// Rejects any property(key) name that is not an identifier,
+// i.e. does not match ^[A-Za-z_][A-Za-z0-9_]*$ once decoded,
+// so that keys map directly to struct fields.
+func WithKeysMustBeIdentifiers() Option {
+	return func(verifier *Verify) error {
+		verifier.KeysMustBeIdentifiers = true
+		return nil
+	}
+}
+
+// WithRejectNumericKeys Option
+// Rejects any property(key) name made only of digits once decoded,
+// like {"0": 1}, which usually is an array serialized as an object.
+func WithRejectNumericKeys() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectNumericKeys = true
+		return nil
+	}
+}
+
+// WithMaxConsecutiveEscapes Option
+// Specifies the maximum number of adjacent escape sequences
+// (like \\ or \n) allowed in a string key or value.
+// The run resets on any non escaped character.
+// zero value disable the checks
+func WithMaxConsecutiveEscapes(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max consecutive escapes cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxConsecutiveEscapes = l
+		verifier.consecutiveEscapesEnabled = true
+		return nil
+	}
+}
+
+// WithMaxScriptsPerString Option
+// Specifies the maximum number of distinct Unicode scripts mixed in
+// each decoded string value, against the homograph attacks like a
+// Cyrillic а in a Latin name. The characters common to the scripts,
+// like digits, punctuation and combining marks, are neutral, and only
+// the scripts of the scriptTables are told apart.
+// zero value disable the checks
+func WithMaxScriptsPerString(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max scripts per string cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxScriptsPerString = l
+		verifier.scriptsEnabled = true
+		return nil
+	}
+}
+
+// WithMaxCombiningMarks Option
+// Specifies the maximum number of adjacent nonspacing combining marks
+// (Unicode category Mn, like U+0301) allowed in each decoded string
+// value, against the Zalgo text stacking marks on one character,
+// while an accented letter like é written with a mark takes one.
+// zero value disable the checks
+func WithMaxCombiningMarks(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max combining marks cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxCombiningMarks = l
+		verifier.combiningMarksEnabled = true
+		return nil
+	}
+}
+
+// scriptTables are the scripts told apart by WithMaxScriptsPerString,
+// at most 32.
+var scriptTables = []*unicode.RangeTable{
+	unicode.Latin, unicode.Greek, unicode.Cyrillic, unicode.Armenian,
+	unicode.Hebrew, unicode.Arabic, unicode.Devanagari, unicode.Bengali,
+	unicode.Thai, unicode.Georgian, unicode.Hangul, unicode.Hiragana,
+	unicode.Katakana, unicode.Han, unicode.Cherokee, unicode.Ethiopic,
+}
+
+// scriptBit returns the bit of the script of r in scriptTables,
+// zero for a neutral or other rune.
+func scriptBit(r rune) uint32 {
+	if r < utf8.RuneSelf {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return 1
+		}
+		return 0
+	}
+	for i, table := range scriptTables {
+		if unicode.Is(table, r) {
+			return 1 << uint(i)
+		}
+	}
+	return 0
+}
+
+// WithMaxTotalEscapes Option
+// Specifies the maximum number of escape sequences (like \\ or \u00e9)
+// allowed in all the string keys and values of a JSON together,
+// which bounds the work of the downstream unescaper when no single
+// string breaks the WithMaxConsecutiveEscapes limit.
+// zero value disable the checks
+func WithMaxTotalEscapes(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max total escapes cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTotalEscapes = l
+		verifier.totalEscapesEnabled = true
+		return nil
+	}
+}
+
+// WithMaxEmptyValues Option
+// Specifies the maximum number of object entries and array elements
+// whose value is null, "", {} or [] in the whole JSON, as payloads
+// full of empty values bloat the decoded maps cheaply.
+// zero value disable the checks
+func WithMaxEmptyValues(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max empty values cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxEmptyValues = l
+		verifier.emptyValuesEnabled = true
+		return nil
+	}
+}
+
+// WithASCIIOnly Option
+// Rejects any byte outside of ASCII anywhere in the JSON, keys and
+// values included. Escape sequences like \u00e9 are ASCII bytes
+// and remain allowed.
+func WithASCIIOnly() Option {
+	return func(verifier *Verify) error {
+		verifier.ASCIIOnly = true
+		return nil
+	}
+}
+
+// WithTolerateRawControlInStrings Option
+// Accepts raw newlines and tabs within string values, as if they
+// were escaped, for producers embedding them by mistake.
+// Other control characters, like NUL, are still rejected.
+// This deliberately relaxes RFC 8259.
+func WithTolerateRawControlInStrings() Option {
+	return func(verifier *Verify) error {
+		verifier.TolerateRawControlInStrings = true
+		return nil
+	}
+}
+
+// WithSkipStringValidation Option
+// Only scans the string values to their closing double quote,
+// without validating their escape sequences and control characters,
+// for a JSON from a trusted producer when only its structure and
+// metrics, like TypeHistogram or MaxDepth, are of interest.
+// UNSAFE: an invalid string value passes the verification, the
+// keys are still validated.
+func WithSkipStringValidation() Option {
+	return func(verifier *Verify) error {
+		verifier.SkipStringValidation = true
+		return nil
+	}
+}
+
+// WithSkipNumberValidation Option
+// Only scans the numbers to their last sign, digit, dot or exponent
+// byte, without validating their grammar, see WithSkipStringValidation.
+// UNSAFE: an invalid number like 01 or 1-2 passes the verification.
+// The number content checks, like WithCanonicalNumbers, need the
+// grammar, the numbers are still validated if any is enabled.
+func WithSkipNumberValidation() Option {
+	return func(verifier *Verify) error {
+		verifier.SkipNumberValidation = true
+		return nil
+	}
+}
+
+// WithRequireStructuredTopLevel Option
+// Rejects JSON whose top level value is a bare string, number,
+// boolean or null, as required by the legacy RFC 4627.
+func WithRequireStructuredTopLevel() Option {
+	return func(verifier *Verify) error {
+		verifier.RequireStructuredTopLevel = true
+		return nil
+	}
+}
+
+// WithQuickReject Option
+// Checks the first and last non whitespace bytes of the JSON before
+// walking it, so a truncated JSON, like a partial upload, fails at
+// once instead of after a walk to its end: the last byte must close
+// the value the first byte opens, } for {, ] for [, a double quote
+// for a string, a digit for a number and e or l for the literals.
+// A valid JSON always passes, but a JSON passing is not known valid,
+// e.g. {"a": [} is only rejected by the walk.
+func WithQuickReject() Option {
+	return func(verifier *Verify) error {
+		verifier.QuickReject = true
+		return nil
+	}
+}
+
+// WithHomogeneousArrays Option
+// Rejects the arrays whose elements don't all have the type of their
+// first element, e.g. [1, "two", true] for columnar data, with
+// jtp.heterogeneousArray.Expected-[number].Got-[string].
+// Nested arrays are checked on their own.
+func WithHomogeneousArrays() Option {
+	return func(verifier *Verify) error {
+		verifier.HomogeneousArrays = true
+		return nil
+	}
+}
+
+// WithNullsInHomogeneousArrays Option
+// Like WithHomogeneousArrays, but accepts null elements
+// among the elements of the same type, like [1, null, 3].
+func WithNullsInHomogeneousArrays() Option {
+	return func(verifier *Verify) error {
+		verifier.HomogeneousArrays = true
+		verifier.NullsInHomogeneousArrays = true
+		return nil
+	}
+}
+
+// WithMaxTrueCount Option
+// Specifies the maximum number of true values in the whole JSON, for
+// the consumers enabling a feature per true flag. The false values
+// are not counted.
+// zero value disable the checks
+func WithMaxTrueCount(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max true count cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTrueCount = l
+		verifier.trueCountEnabled = true
+		return nil
+	}
+}
+
+// WithDisallowNull Option
+// Rejects any null value, in an object, an array or at the top level,
+// for the schemas where an absent key replaces null.
+func WithDisallowNull() Option {
+	return func(verifier *Verify) error {
+		verifier.DisallowNull = true
+		return nil
+	}
+}
+
+// WithTopLevelMustBeNonEmpty Option
+// Rejects JSON whose top level value is an empty object or array,
+// {} or [], with ErrEmptyTopLevelContainer. The nested containers
+// may still be empty.
+func WithTopLevelMustBeNonEmpty() Option {
+	return func(verifier *Verify) error {
+		verifier.TopLevelMustBeNonEmpty = true
+		return nil
+	}
+}
+
+// WithMaxScanBudget Option
+// Specifies the maximum number of bytes the verification scans, to
+// bound the CPU spent even on a valid JSON: each byte walked,
+// whitespace included, counts once, and each string counts again for
+// every enabled check rescanning it, like the string length or
+// content checks, as does the JSON for the WithASCIIOnly pre-scan.
+// zero value disable the checks
+func WithMaxScanBudget(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max scan budget cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxScanBudget = l
+		verifier.scanBudgetEnabled = true
+		return nil
+	}
+}
+
+// WithMaxTokenGap Option
+// Specifies the maximum number of bytes the verification advances
+// between two structural tokens, { } [ ] : and comma, or the start
+// and end of the JSON, so a long string, number or whitespace run
+// all break it, e.g. in [1,  "abc"] the gap before ] is 7 bytes.
+// zero value disable the checks
+func WithMaxTokenGap(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max token gap cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTokenGap = l
+		verifier.tokenGapEnabled = true
+		return nil
+	}
+}
+
+// WithMaxTokenBytes Option
+// Specifies the maximum number of raw bytes of a single token, a
+// string or key with its double quotes and escapes as written, a
+// number or a literal. VerifyReader stops reading as soon as a token
+// exceeds it, so an unterminated string or number is not buffered up
+// to the MaxDocumentSize, in this case the reported Found is a lower
+// bound.
+// zero value disable the checks
+func WithMaxTokenBytes(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max token bytes cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTokenBytes = l
+		verifier.tokenBytesEnabled = true
+		return nil
+	}
+}
+
+// WithMaxDecodedMemoryEstimate Option
+// Specifies the maximum estimated memory in bytes a decoded JSON
+// would consume, accumulated over the whole JSON as the bytes of
+// every string key and value plus a fixed cost per object entry and
+// per array element, DefaultMemoryCosts unless WithDecodedMemoryCosts
+// is passed.
+// zero value disable the checks
+func WithMaxDecodedMemoryEstimate(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max decoded memory estimate cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxDecodedMemoryEstimate = l
+		verifier.decodedMemoryEnabled = true
+		if verifier.DecodedMemoryCosts == (MemoryCosts{}) {
+			verifier.DecodedMemoryCosts = DefaultMemoryCosts
+		}
+		return nil
+	}
+}
+
+// WithDecodedMemoryCosts Option
+// Specifies the costs used by WithMaxDecodedMemoryEstimate.
+func WithDecodedMemoryCosts(c MemoryCosts) Option {
+	return func(verifier *Verify) error {
+		if c.ObjectEntry < 0 || c.ArrayElement < 0 {
+			return fmt.Errorf("jtp: decoded memory costs cannot be"+
+				" negative %v", c)
+		}
+		verifier.DecodedMemoryCosts = c
+		return nil
+	}
+}
+
+// WithMaxColonSurroundingWhitespace Option
+// Specifies the maximum number of whitespace bytes allowed around
+// each colon of an object, before and after it combined.
+// zero value disable the checks
+func WithMaxColonSurroundingWhitespace(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max colon whitespace cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxColonWhitespace = l
+		verifier.colonWhitespaceEnabled = true
+		return nil
+	}
+}
+
+// WithMaxDocumentSize Option
+// Specifies the maximum size in bytes of the whole JSON document,
+// for compressed input it is the size after decompression.
+// The readers of VerifyReader and VerifyOne are never read more than
+// one byte past it, so an endless stream is rejected early.
+// zero value disable the checks
+func WithMaxDocumentSize(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max document size cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxDocumentSize = l
+		verifier.documentSizeEnabled = true
+		return nil
+	}
+}
+
+// WithAllowlist Option
+// Specifies known good documents, like health checks, that
+// VerifyBytes and VerifyString pass as soon as the input is exactly
+// one of them, byte for byte, without validating it.
+// It can be passed multiple times to allow more documents.
+func WithAllowlist(docs [][]byte) Option {
+	return func(verifier *Verify) error {
+		allowlist := make(map[string]struct{},
+			len(verifier.allowlist)+len(docs))
+		for doc := range verifier.allowlist {
+			allowlist[doc] = struct{}{}
+		}
+		for _, doc := range docs {
+			allowlist[string(doc)] = struct{}{}
+		}
+		verifier.allowlist = allowlist
+		return nil
+	}
+}
+
+// WithDepthExemptKey Option
+// Specifies a key whose value subtree doesn't count toward the
+// maximum container depth, for a legitimately deep field while
+// keeping a strict depth everywhere else.
+// It can be passed multiple times to exempt several keys.
+func WithDepthExemptKey(key string) Option {
+	return func(verifier *Verify) error {
+		verifier.DepthExemptKeys = append(verifier.DepthExemptKeys, key)
+		return nil
+	}
+}
+
+// WithRequiredTopLevelKeys Option
+// Specifies keys the top level value must have, e.g. the version and
+// data of an envelope, so the JSON must be an object with each of
+// them, failing once it's validated with the first one missing.
+// It can be passed multiple times, adding to the keys.
+func WithRequiredTopLevelKeys(keys ...string) Option {
+	return func(verifier *Verify) error {
+		verifier.RequiredTopLevelKeys = append(
+			verifier.RequiredTopLevelKeys, keys...)
+		return nil
+	}
+}
+
+// WithAllowedTopLevelKeys Option
+// Specifies the only keys the top level object can have, e.g. the
+// version and data of an envelope, failing on the first other key
+// with unexpectedTopLevelKey. Unlike WithRequiredTopLevelKeys the
+// keys can be missing, and the JSON doesn't need to be an object.
+// It can be passed multiple times, adding to the keys, without keys
+// the top level object must be empty.
+func WithAllowedTopLevelKeys(keys ...string) Option {
+	return func(verifier *Verify) error {
+		verifier.AllowedTopLevelKeys = append(
+			verifier.AllowedTopLevelKeys, keys...)
+		verifier.allowedTopLevelKeysEnabled = true
+		return nil
+	}
+}
+
+// DefaultTrackedValueLength is the default length in bytes of the
+// longest value counted by WithMaxIdenticalValueRepeats.
+const DefaultTrackedValueLength = 64
+
+// maxTrackedValues bounds the distinct values counted by
+// WithMaxIdenticalValueRepeats.
+const maxTrackedValues = 4096
+
+// WithMaxIdenticalValueRepeats Option
+// Specifies the maximum number of occurrences of a same scalar value,
+// as written in the JSON, across the document, e.g. [1,1,1,...]
+// that decode into large repetitive structures.
+// To bound the memory only the first distinct values up to the
+// TrackedValueLength bytes are counted, see WithTrackedValueLength.
+// zero value disable the checks
+func WithMaxIdenticalValueRepeats(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max identical value repeats cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxIdenticalValueRepeats = l
+		if verifier.TrackedValueLength == 0 {
+			verifier.TrackedValueLength = DefaultTrackedValueLength
+		}
+		verifier.identicalRepeatsEnabled = true
+		return nil
+	}
+}
+
+// minFractionValues is the number of scalar values a JSON must have
+// for WithMaxValueRepetitionFraction to apply.
+const minFractionValues = 16
+
+// WithMaxValueRepetitionFraction Option
+// Specifies the maximum fraction, from 0 to 1, of the scalar values of
+// the JSON a same value, as written, can be, e.g. 0.5 to reject a JSON
+// padded with a repeated value, checked once the JSON is walked.
+// A JSON with less than 16 scalar values passes, as a few values make
+// any fraction large. To bound the memory only the first distinct
+// values up to the TrackedValueLength bytes are counted, see
+// WithTrackedValueLength, but all the values count in the total.
+// zero value disable the checks
+func WithMaxValueRepetitionFraction(frac float64) Option {
+	return func(verifier *Verify) error {
+		if frac == 0 {
+			return nil
+		}
+		if !(frac > 0 && frac < 1) {
+			return fmt.Errorf("jtp: max value repetition fraction must be"+
+				" between 0 and 1 %g", frac)
+		}
+		verifier.MaxValueRepetitionFraction = frac
+		if verifier.TrackedValueLength == 0 {
+			verifier.TrackedValueLength = DefaultTrackedValueLength
+		}
+		verifier.valueFractionEnabled = true
+		return nil
+	}
+}
+
+// WithTrackedValueLength Option
+// Specifies the length in bytes of the longest value counted by
+// WithMaxIdenticalValueRepeats and WithMaxValueRepetitionFraction.
+func WithTrackedValueLength(l int) Option {
+	return func(verifier *Verify) error {
+		if l <= 0 {
+			return fmt.Errorf("jtp: tracked value length must be"+
+				" positive %d", l)
+		}
+		verifier.TrackedValueLength = l
+		return nil
+	}
+}
+
+// DefaultTrackedKeyLength is the default length in bytes of the
+// longest property name counted by WithMaxGlobalKeyRepeats.
+const DefaultTrackedKeyLength = 64
+
+// WithMaxGlobalKeyRepeats Option
+// Specifies the maximum number of occurrences of a same property(key)
+// name, as written in the JSON, across the whole document, e.g. a
+// key in each of millions of array element objects.
+// To bound the memory only the first distinct names up to the
+// TrackedKeyLength bytes are counted, see WithTrackedKeyLength.
+// zero value disable the checks
+func WithMaxGlobalKeyRepeats(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max global key repeats cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxGlobalKeyRepeats = l
+		if verifier.TrackedKeyLength == 0 {
+			verifier.TrackedKeyLength = DefaultTrackedKeyLength
+		}
+		verifier.keyRepeatsEnabled = true
+		return nil
+	}
+}
+
+// WithTrackedKeyLength Option
+// Specifies the length in bytes of the longest property name counted
+// by WithMaxGlobalKeyRepeats.
+func WithTrackedKeyLength(l int) Option {
+	return func(verifier *Verify) error {
+		if l <= 0 {
+			return fmt.Errorf("jtp: tracked key length must be"+
+				" positive %d", l)
+		}
+		verifier.TrackedKeyLength = l
+		return nil
+	}
+}
+
+// WithMaxContainerCount Option
+// Specifies the maximum total number of containers (objects and
+// arrays) allowed in the whole JSON, regardless of their nesting.
+// zero value disable the checks
+func WithMaxContainerCount(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max container count cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxContainerCount = l
+		verifier.containerCountEnabled = true
+		return nil
+	}
+}
+
+// WithMaxTotalObjects Option
+// Specifies the maximum number of objects allowed in the whole JSON,
+// for documents with millions of tiny objects that pass every
+// per container limit.
+// zero value disable the checks
+func WithMaxTotalObjects(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max total objects cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTotalObjects = l
+		verifier.totalObjectsEnabled = true
+		return nil
+	}
+}
+
+// WithMinAverageObjectFill Option
+// Specifies the minimum average number of entries of the objects of
+// the JSON, i.e. its object entries divided by its objects, checked
+// once the JSON is walked, for documents made of many tiny objects,
+// like [{"a": 1}, {"b": 2}, ...]. A JSON without objects passes.
+// zero value disable the checks
+func WithMinAverageObjectFill(avg float64) Option {
+	return func(verifier *Verify) error {
+		if avg == 0 {
+			return nil
+		}
+		if !(avg > 0) || math.IsInf(avg, 1) {
+			return fmt.Errorf("jtp: min average object fill must be"+
+				" positive and finite %g", avg)
+		}
+		verifier.MinAverageObjectFill = avg
+		verifier.objectFillEnabled = true
+		return nil
+	}
+}
+
+// WithMaxStructuralStates Option
+// Specifies the maximum number of distinct (depth, container type)
+// states visited in the JSON, a crude measure of its structural
+// complexity: a uniform JSON visits few states.
+// zero value disable the checks
+func WithMaxStructuralStates(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max structural states cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxStructuralStates = l
+		verifier.structuralStatesEnabled = true
+		return nil
+	}
+}
+
+// WithMaxStructureTransitions Option
+// Specifies the maximum number of transitions between arrays and
+// objects in the whole JSON, i.e. of arrays entered within an object
+// and of objects entered within an array, e.g. 3 in {"a": [{"b": [1]}]},
+// a measure of the structural complexity distinct from the depth and
+// the container count: nested arrays or nested objects have none.
+// zero value disable the checks
+func WithMaxStructureTransitions(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max structure transitions cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxStructureTransitions = l
+		verifier.transitionsEnabled = true
+		return nil
+	}
+}
+
+// WithMaxConsecutiveOpens Option
+// Specifies the maximum number of containers opened in a row, without
+// a scalar value or a closed container in between, the keys don't
+// count, e.g. 4 for [[{"a": [1]}]], which stops a [[[[... bomb at its
+// first bytes. It is cheaper than the depth limit, for a hot path.
+// zero value disable the checks
+func WithMaxConsecutiveOpens(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max consecutive opens cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxConsecutiveOpens = l
+		verifier.consecutiveOpensEnabled = true
+		return nil
+	}
+}
+
+// WithMaxBytesPerChild Option
+// Specifies the maximum size in bytes of a container, from its opening
+// to its closing byte, divided by its number of elements or entries,
+// to catch the fat containers with few children, like an object of 2
+// entries holding megabytes. The empty containers are not checked.
+// The containers are checked once closed, with
+// jtp.maxBytesPerChildReached.Container-[object].Max-[X]-Allowed.Found-[Y].
+// zero value disable the checks
+func WithMaxBytesPerChild(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max bytes per child cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxBytesPerChild = l
+		verifier.bytesPerChildEnabled = true
+		return nil
+	}
+}
+
+// WithRejectLossyNumbers Option
+// Rejects any number whose value changes when parsed as float64,
+// i.e. the shortest formatting of the parsed float64 does not have
+// the same decimal value as the number, like 9007199254740993.
+// It parses every number so it is expensive.
+func WithRejectLossyNumbers() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectLossyNumbers = true
+		return nil
+	}
+}
+
+// WithRejectUnderflowNumbers Option
+// Rejects any non zero number that parses to zero as float64,
+// like 1e-400, as it silently becomes 0 downstream.
+// It parses every number so it is expensive.
+func WithRejectUnderflowNumbers() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectUnderflowNumbers = true
+		return nil
+	}
+}
+
+// WithRejectOverflowNumbers Option
+// Rejects any number out of the float64 range, like 1e309,
+// as it becomes an infinity downstream.
+// It parses every number so it is expensive.
+func WithRejectOverflowNumbers() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectOverflowNumbers = true
+		return nil
+	}
+}
+
+// WithRejectNegativeZero Option
+// Rejects the negative zero numbers, -0 with any zero fraction or
+// exponent, like -0.0 or -0e5, as they round-trip inconsistently,
+// -0 in JavaScript but 0 once decoded to an integer.
+func WithRejectNegativeZero() Option {
+	return func(verifier *Verify) error {
+		verifier.RejectNegativeZero = true
+		return nil
+	}
+}
+
+// WithPlainDecimalNumbersOnly Option
+// Rejects any number with an exponent part, like 1e3,
+// for numbers that are IDs or amounts.
+func WithPlainDecimalNumbersOnly() Option {
+	return func(verifier *Verify) error {
+		verifier.PlainDecimalNumbersOnly = true
+		return nil
+	}
+}
+
+// WithCanonicalNumbers Option
+// Rejects any number not written in its canonical form, for systems
+// requiring byte-stable numbers. A number is canonical without a
+// negative zero, without trailing zeros in its fraction, 1.5 and not
+// 1.50 or 1.0, and if it has an exponent in normalized scientific
+// notation: one nonzero digit before the dot, a lowercase e, no plus
+// sign and no leading zero in the exponent, 1.5e10 and not 15e9,
+// 1.5E10, 1.5e+10, 1.5e010 or 1e0.
+func WithCanonicalNumbers() Option {
+	return func(verifier *Verify) error {
+		verifier.CanonicalNumbers = true
+		return nil
+	}
+}
+
+// WithMaxExponentDigits Option
+// Specifies the maximum number of digits after the e or E and its
+// optional sign of a number, like 5 for 1e12345, whatever the
+// magnitude, as arbitrary-precision decoders allocate per digit.
+// zero value disable the checks
+func WithMaxExponentDigits(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max exponent digits cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxExponentDigits = l
+		verifier.exponentDigitsEnabled = true
+		return nil
+	}
+}
+
+// WithMaxNumberDigits Option
+// Specifies the maximum number of digits of a number, counting the
+// integer, fraction and exponent digits together but not the sign,
+// dot or e, like 4 for -1.5e10 and 10 for 1234567890, to flag long
+// digit strings which look like encoded data rather than values.
+// zero value disable the checks
+func WithMaxNumberDigits(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max number digits cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxNumberDigits = l
+		verifier.numberDigitsEnabled = true
+		return nil
+	}
+}
+
+// WithRequireStringifiedLargeNumbers Option
+// Specifies the maximum number of significant digits of a bare number,
+// its integer and fraction digits without the leading zeros, like 5
+// for 12345 and 0.00123, so the numbers a float64 can't hold exactly,
+// like the 64-bit ids, must be sent as strings, "12345678901234567890",
+// as the JavaScript APIs do. The exponent is not counted.
+// zero value disable the checks
+func WithRequireStringifiedLargeNumbers(maxBareDigits int) Option {
+	return func(verifier *Verify) error {
+		if maxBareDigits == 0 {
+			return nil
+		}
+		if maxBareDigits < 0 {
+			return fmt.Errorf("jtp: max bare digits cannot be"+
+				" negative %d", maxBareDigits)
+		}
+		verifier.MaxBareDigits = maxBareDigits
+		verifier.bareDigitsEnabled = true
+		return nil
+	}
+}
+
+// WithMaxFloatCount Option
+// Specifies the maximum number of numbers with a fraction or an
+// exponent part, like 1.5 or 1e3, in the whole JSON, for the targets
+// where the floats are costly to store. The integers are not counted.
+// zero value disable the checks
+func WithMaxFloatCount(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max float count cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxFloatCount = l
+		verifier.floatCountEnabled = true
+		return nil
+	}
+}
+
+// WithMaxTotalNumberBytes Option
+// Specifies the maximum number of bytes of all the number tokens of
+// the JSON combined, as written, for the arbitrary precision decimal
+// targets whose cost grows with the digits. The string values are not
+// counted.
+// zero value disable the checks
+func WithMaxTotalNumberBytes(l int) Option {
+	return func(verifier *Verify) error {
+		if l == 0 {
+			return nil
+		}
+		if l < 0 {
+			return fmt.Errorf("jtp: max total number bytes cannot be"+
+				" negative %d", l)
+		}
+		verifier.MaxTotalNumberBytes = l
+		verifier.totalNumberBytesEnabled = true
+		return nil
+	}
+}
+
+// WithInt64RangeNumbers Option
+// Rejects the integer numbers, without fraction or exponent, out of
+// the int64 range, like 9223372036854775808, with
+// jtp.int64RangeExceeded.Token-[9223372036854775808], before they
+// overflow the int64 fields they are decoded into.
+// The other numbers are left to the other options.
+func WithInt64RangeNumbers() Option {
+	return func(verifier *Verify) error {
+		verifier.Int64RangeNumbers = true
+		return nil
+	}
+}
+
+// WithNumberValidator Option
+// Specifies a validator called with each number token, to enforce
+// a numeric policy no option expresses. The token is the raw slice
+// of the JSON, already a valid number, and must not be modified or
+// retained after the call. A non nil error fails the verification
+// with a *RejectedError wrapping it.
+func WithNumberValidator(fn func(token []byte) error) Option {
+	return func(verifier *Verify) error {
+		if fn == nil {
+			return errors.New("jtp: number validator cannot be nil")
+		}
+		verifier.numberValidator = fn
+		return nil
+	}
+}
+
+// numberContentEnabled reports if any check on the value
+// of a number is enabled.
+func (v *Verify) numberContentEnabled() bool {
+	return v.RejectLossyNumbers || v.RejectUnderflowNumbers ||
+		v.RejectOverflowNumbers || v.RejectNegativeZero ||
+		v.PlainDecimalNumbersOnly ||
+		v.CanonicalNumbers ||
+		v.Int64RangeNumbers ||
+		v.exponentDigitsEnabled || v.numberDigitsEnabled ||
+		v.bareDigitsEnabled ||
+		v.numberValidator != nil
+}
+
+// WithStringValueCharset Option
+// Specifies the characters allowed in the decoded string values,
+// allowed reports if a rune is allowed.
+func WithStringValueCharset(allowed func(rune) bool) Option {
+	return func(verifier *Verify) error {
+		if allowed == nil {
+			return errors.New("jtp: string value charset cannot be nil")
+		}
+		verifier.stringValueCharset = allowed
+		return nil
+	}
+}
+
+// WithStringValidator Option
+// Specifies a validator called with each string key (isKey) and
+// value, to enforce a string policy like a denylist in one place.
+// The value is the raw slice of the JSON between the double quotes,
+// with its escape sequences not decoded, so "\u0041" is not "A",
+// and must not be modified or retained after the call. A non nil
+// error fails the verification with a *RejectedError wrapping it.
+func WithStringValidator(fn func(value []byte, isKey bool) error) Option {
+	return func(verifier *Verify) error {
+		if fn == nil {
+			return errors.New("jtp: string validator cannot be nil")
+		}
+		verifier.stringValidator = fn
+		return nil
+	}
+}
+
+// stringContentEnabled reports if any check on the decoded content
+// of a string key (isKey) or value is enabled.
+func (v *Verify) stringContentEnabled(isKey bool) bool {
+	return v.consecutiveEscapesEnabled || v.RejectNullCharInStrings ||
+		v.RejectNoncharacters || v.RejectExtendedControls ||
+		v.stringValidator != nil || v.ForwardSlashPolicy != SlashAllow ||
+		(isKey && (v.KeysMustBeIdentifiers || v.RejectNumericKeys)) ||
+		(!isKey && (v.RejectReplacementChar || v.stringValueCharset != nil ||
+			v.scriptsEnabled || v.combiningMarksEnabled ||
+			v.RejectZeroWidthChars))
+}
+
+// validateStringLength checks the length of the valid string span,
+// as measured by measure, like decodedRuneCount.
+func validateStringLength(data []byte, startIndex, endIndex int,
+	enabled bool, maxAllowed int, strType string,
+	measure func(data []byte, startIndex, endIndex int) int) (err error) {
+	if !enabled {
+		return
+	}
+	l := measure(data, startIndex, endIndex)
+	if l > maxAllowed {
+		err = newLimitError(strType, maxAllowed, l, startIndex)
+	}
+	return
+}
+
+// decodedRuneCount returns the number of code points in the decoded
+// content of the valid string span, excluding the double quotes.
+// An escaped surrogate pair counts as its single code point and an
+// unpaired surrogate escape as one.
+// JSON exchange in an open ecosystem must be encoded in UTF-8.
+// https://tools.ietf.org/html/rfc8259#section-8.1
+func decodedRuneCount(data []byte, startIndex, endIndex int) (n int) {
+	str := data[startIndex+1 : endIndex-1]
+	if bytes.IndexByte(str, '\\') < 0 {
+		return utf8.RuneCount(str)
+	}
+	for i := startIndex + 1; i < endIndex-1; n++ {
+		_, size := decodeStringRune(data, i)
+		i += size
+	}
+	return
+}
+
+// decodedUTF16Units returns the number of UTF-16 code units
+// of the decoded content of the valid string span.
+func decodedUTF16Units(data []byte, startIndex, endIndex int) int {
+	l := 0
+	// skip the enclosing double quotes
+	for i := startIndex + 1; i < endIndex-1; {
+		r, size := decodeStringRune(data, i)
+		// outside the BMP is encoded as a surrogate pair
+		if r > 0xFFFF {
+			l += 2
+		} else {
+			l++
+		}
+		i += size
+	}
+	return l
+}
+
+// decodedByteLength returns the number of UTF-8 bytes
+// of the decoded content of the valid string span.
+func decodedByteLength(data []byte, startIndex, endIndex int) (l int) {
+	str := data[startIndex+1 : endIndex-1]
+	if bytes.IndexByte(str, '\\') < 0 {
+		return len(str)
+	}
+	for i := startIndex + 1; i < endIndex-1; {
+		r, size := decodeStringRune(data, i)
+		if data[i] == '\\' {
+			n := utf8.RuneLen(r)
+			if n < 0 {
+				// lone surrogate, decoded as U+FFFD
+				n = utf8.RuneLen(utf8.RuneError)
+			}
+			l += n
+		} else {
+			// raw bytes are kept as is
+			l += size
+		}
+		i += size
+	}
+	return
+}
+
+// validateStringContent walks the decoded runes of a valid string
+// key (isKey) or value span and applies the enabled content checks.
+func validateStringContent(data []byte, startIndex, endIndex int,
+	isKey bool, verifier *Verify) (err error) {
+	escapes := 0
+	if isKey && verifier.KeysMustBeIdentifiers && startIndex+2 == endIndex {
+		return fmt.Errorf("jtp.keyNotIdentifier.Key-[]")
+	}
+	numeric := isKey && verifier.RejectNumericKeys && startIndex+2 < endIndex
+	var scripts uint32
+	marks := 0
+	// skip the enclosing double quotes
+	for i := startIndex + 1; i < endIndex-1; {
+		r, size := decodeStringRune(data, i)
+		numeric = numeric && r >= '0' && r <= '9'
+		if isKey && verifier.KeysMustBeIdentifiers &&
+			!isIdentifierRune(r, i == startIndex+1) {
+			return fmt.Errorf("jtp.keyNotIdentifier.Key-[%s]",
+				appendDecodedString(nil, data, startIndex, endIndex))
+		}
+		if data[i] == '\\' {
+			escapes++
+			if verifier.consecutiveEscapesEnabled &&
+				escapes > verifier.MaxConsecutiveEscapes {
+				return newLimitError(consecutiveEscapes,
+					verifier.MaxConsecutiveEscapes, escapes, i)
+			}
+		} else {
+			escapes = 0
+		}
+		if r == '/' && verifier.ForwardSlashPolicy.rejects(data[i] == '\\') {
+			return fmt.Errorf("jtp.forwardSlashPolicyViolation.Offset-[%d]", i)
+		}
+		if r == 0 && verifier.RejectNullCharInStrings {
+			return fmt.Errorf("jtp.nullCharacterInString.Offset-[%d]", i)
+		}
+		if verifier.RejectNoncharacters && isNoncharacter(r) {
+			return fmt.Errorf("jtp.unicodeNoncharacter.CodePoint-[%U]", r)
+		}
+		if verifier.RejectExtendedControls && r >= 0x7F && r <= 0x9F {
+			return fmt.Errorf("jtp.extendedControlCharacter.CodePoint-[%U]", r)
+		}
+		if !isKey && verifier.RejectZeroWidthChars &&
+			((r >= 0x200B && r <= 0x200D) || r == 0xFEFF) {
+			return fmt.Errorf("jtp.zeroWidthCharacter.CodePoint-[%U]."+
+				"Offset-[%d]", r, i)
+		}
+		// size 1 is an invalid byte, not an encoded U+FFFD
+		if !isKey && verifier.RejectReplacementChar &&
+			r == utf8.RuneError && size > 1 {
+			return fmt.Errorf("jtp.replacementCharacterPresent."+
+				"Offset-[%d]", i)
+		}
+		if !isKey && verifier.stringValueCharset != nil &&
+			!verifier.stringValueCharset(r) {
+			return fmt.Errorf("jtp.disallowedStringCharacter."+
+				"Char-[%c].Offset-[%d]", r, i)
+		}
+		if !isKey && verifier.combiningMarksEnabled {
+			if r >= utf8.RuneSelf && unicode.Is(unicode.Mn, r) {
+				marks++
+				if marks > verifier.MaxCombiningMarks {
+					return newLimitError(combiningMarks,
+						verifier.MaxCombiningMarks, marks, i)
+				}
+			} else {
+				marks = 0
+			}
+		}
+		if !isKey && verifier.scriptsEnabled {
+			if bit := scriptBit(r); bit != 0 && scripts&bit == 0 {
+				scripts |= bit
+				if n := bits.OnesCount32(scripts); n > verifier.MaxScriptsPerString {
+					return newLimitError(stringScripts,
+						verifier.MaxScriptsPerString, n, i)
+				}
+			}
+		}
+		i += size
+	}
+	if numeric {
+		return fmt.Errorf("jtp.numericObjectKey.Key-[%s]",
+			appendDecodedString(nil, data, startIndex, endIndex))
+	}
+	if verifier.stringValidator != nil {
+		err = verifier.stringValidator(data[startIndex+1:endIndex-1], isKey)
+		if err != nil {
+			kind := "string"
+			if isKey {
+				kind = "key"
+			}
+			return &RejectedError{Kind: kind, Offset: startIndex, Err: err}
+		}
+	}
+	return
+}
+
+// isIdentifierRune reports if r is allowed in an identifier,
+// digits are not allowed first.
+func isIdentifierRune(r rune, first bool) bool {
+	return r == '_' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') ||
+		(!first && r >= '0' && r <= '9')
+}
+
+// decodeStringRune decodes the rune at data[i] of a valid string,
+// resolving escape sequences and surrogate pairs.
+// It returns the rune and the number of bytes it spans in data.
+func decodeStringRune(data []byte, i int) (r rune, size int) {
+	if data[i] != '\\' {
+		return utf8.DecodeRune(data[i:])
+	}
+	switch data[i+1] {
+	case 'b':
+		return '\b', 2
+	case 'f':
+		return '\f', 2
+	case 'n':
+		return '\n', 2
+	case 'r':
+		return '\r', 2
+	case 't':
+		return '\t', 2
+	case 'u':
+		r = hexRune(data[i+2 : i+6])
+		if utf16.IsSurrogate(r) && i+12 <= len(data) &&
+			data[i+6] == '\\' && data[i+7] == 'u' {
+			if dec := utf16.DecodeRune(r,
+				hexRune(data[i+8:i+12])); dec != utf8.RuneError {
+				return dec, 12
+			}
+		}
+		return r, 6
+	default:
+		// '"', '\\' and '/'
+		return rune(data[i+1]), 2
+	}
+}
+
+// appendDecodedString appends the decoded content of the valid
+// string span, excluding the double quotes, to dst.
+func appendDecodedString(dst, data []byte, startIndex,
+	endIndex int) []byte {
+	var buf [utf8.UTFMax]byte
+	for i := startIndex + 1; i < endIndex-1; {
+		if data[i] != '\\' {
+			dst = append(dst, data[i])
+			i++
+			continue
+		}
+		r, size := decodeStringRune(data, i)
+		n := utf8.EncodeRune(buf[:], r)
+		dst = append(dst, buf[:n]...)
+		i += size
+	}
+	return dst
+}
+
+// stringIn reports if the decoded content of the valid string span
+// is one of strs.
+func stringIn(data []byte, startIndex, endIndex int, strs []string) bool {
+	for _, str := range strs {
+		if stringEquals(data, startIndex, endIndex, str) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringEquals reports if the decoded content of the valid string
+// span equals str.
+func stringEquals(data []byte, startIndex, endIndex int,
+	str string) bool {
+	var buf [utf8.UTFMax]byte
+	j := 0
+	for i := startIndex + 1; i < endIndex-1; {
+		if data[i] != '\\' {
+			if j >= len(str) || str[j] != data[i] {
+				return false
+			}
+			i++
+			j++
+			continue
+		}
+		r, size := decodeStringRune(data, i)
+		n := utf8.EncodeRune(buf[:], r)
+		if j+n > len(str) || str[j:j+n] != string(buf[:n]) {
+			return false
+		}
+		i += size
+		j += n
+	}
+	return j == len(str)
+}
+
+// hexRune converts 4 validated hex digits into a rune.
+func hexRune(hex []byte) (r rune) {
+	for _, c := range hex {
+		switch {
+		case c >= '0' && c <= '9':
+			c -= '0'
+		case c >= 'a' && c <= 'f':
+			c = c - 'a' + 10
+		default:
+			c = c - 'A' + 10
+		}
+		r = r<<4 | rune(c)
+	}
+	return
+}
+
+// isValidateString checks if the string is valid or not,
+// tolerateRawControl accepts raw newlines and tabs within.
+func isValidateString(data []byte, i int, tolerateRawControl bool) (outi int,
+	ok bool) {
+	for ; i < len(data); i++ {
+		if data[i] < ' ' {
+			if tolerateRawControl && (data[i] == '\n' || data[i] == '\t') {
+				continue
+			}
+			return i, false
+		} else if data[i] == '\\' {
+			//
+			i++
+			if i == len(data) {
+				return i, false
+			}
+			switch data[i] {
+			default:
+				return i, false
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			case 'u':
+				for j := 0; j < 4; j++ {
+					i++
+					if i >= len(data) {
+						return i, false
+					}
+					if !((data[i] >= '0' && data[i] <= '9') ||
+						(data[i] >= 'a' && data[i] <= 'f') ||
+						(data[i] >= 'A' && data[i] <= 'F')) {
+						return i, false
+					}
+				}
+			}
+		} else if data[i] == '"' {
+			return i + 1, true
+		}
+	}
+	return i, false
+}
+
+// skipString returns the offset after the closing double quote of
+// the string, without validating it. The four bytes of a \u escape are
+// skipped whatever they are, so the escapes stay within the string
+// span for the decoding checks.
+func skipString(data []byte, i int) (outi int, ok bool) {
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			if i+1 < len(data) && data[i+1] == 'u' {
+				i += 5
+			} else {
+				i++
+			}
+		case '"':
+			return i + 1, true
+		}
+	}
+	return len(data), false
+}
+
+// isValidateKey checks if the key string is valid as isValidateString
+// and, in the same pass, counts the code points of its decoded content
+// as decodedRuneCount, so the keys are not traversed twice.
+func isValidateKey(data []byte, i int) (outi, runes int, ok bool) {
+	// a high surrogate escape waiting for its low surrogate
+	high := rune(-1)
+	for ; i < len(data); i++ {
+		c := data[i]
+		if c < ' ' {
+			return i, runes, false
+		} else if c == '"' {
+			return i + 1, runes, true
+		} else if c >= utf8.RuneSelf {
+			_, size := utf8.DecodeRune(data[i:])
+			i += size - 1
+			runes++
+			high = -1
+		} else if c != '\\' {
+			runes++
+			high = -1
+		} else {
+			i++
+			if i == len(data) {
+				return i, runes, false
+			}
+			switch data[i] {
+			default:
+				return i, runes, false
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				runes++
+				high = -1
+			case 'u':
+				for j := 1; j <= 4; j++ {
+					if i+j >= len(data) {
+						return i + j, runes, false
+					}
+					if c := data[i+j]; !((c >= '0' && c <= '9') ||
+						(c >= 'a' && c <= 'f') ||
+						(c >= 'A' && c <= 'F')) {
+						return i + j, runes, false
+					}
+				}
+				r := hexRune(data[i+1 : i+5])
+				i += 4
+				// a surrogate pair decodes to a single code point
+				if high >= 0 && utf16.DecodeRune(high, r) != utf8.RuneError {
+					high = -1
+					continue
+				}
+				runes++
+				high = -1
+				if r >= 0xD800 && r < 0xDC00 {
+					high = r
+				}
+			}
+		}
+	}
+	return i, runes, false
+}
+
+// checkpointInterval is the number of values walked
+// between two checks of the context for cancellation.
+const checkpointInterval = 1024
+
+// state holds the bookkeeping of a single verification walk.
+type state struct {
+	verifier Verify
+	depth    int
+	// maxDepth is the maximum depth reached so far.
+	maxDepth int
+	// tokenEnd is the offset after the last structural token.
+	tokenEnd int
+	// requiredSeen has the index of each required top level key seen.
+	requiredSeen bitset
+	// ctx if set is checked every checkpointInterval values.
+	ctx   context.Context
+	steps int
+	// observed if set records the maximum seen for each limit.
+	observed *observed
+	// memory is the estimated decoded memory so far.
+	memory int
+	// containers is the number of containers entered so far.
+	containers int
+	// states is the set of visited (depth, container type) states,
+	// as a bitset keyed by depth<<1|isArray.
+	states      []uint64
+	statesCount int
+	// transitions is the number of containers entered within a
+	// container of the other type.
+	transitions int
+	// opens is the number of containers opened in a row.
+	opens int
+	// h if set receives the structural events of the walk.
+	h handler
+	// exemptPending is set when the next value is under a depth
+	// exempt key, exemptDepth counts the containers entered since.
+	exemptPending bool
+	exemptDepth   int
+	// relaxPending is set when the next value is under a key
+	// relaxed from the string length limit.
+	relaxPending bool
+	// urlKey is the URL key the next value is under, if any.
+	urlKey string
+	// format is the rule of the format key the next value is under,
+	// with a zero format if none.
+	format formatRule
+	// epoch is the rule of the epoch key the next value is under,
+	// nil if none.
+	epoch *epochRule
+	// arrayOverride is set when the next value is under a key with
+	// an array limit override, arrayMax is its limit, and the same
+	// for the object entry limit overrides.
+	arrayOverride  bool
+	arrayMax       int
+	objectOverride bool
+	objectMax      int
+	// keyBytes is the byte length of all the keys so far.
+	keyBytes int
+	// escapes is the number of escape sequences so far.
+	escapes int
+	// floats is the number of numbers with a fraction or an exponent
+	// so far.
+	floats int
+	// numberBytes is the byte length of all the numbers so far.
+	numberBytes int
+	// trues is the number of true values so far.
+	trues int
+	// emptyValues is the number of empty values so far.
+	emptyValues int
+	// end is the offset where the walk of verify stopped.
+	end int
+	// rescanned is the number of bytes scanned again by the checks.
+	rescanned int
+	// streamBytes is the size of the JSON verified before
+	// by a StreamValidator.
+	streamBytes int
+	// streamKeys has the top level keys of the JSON verified before
+	// by a StreamValidator and newKeys the ones first seen in this one.
+	streamKeys map[string]struct{}
+	newKeys    []string
+	// entries is the number of object entries so far, objects the
+	// number of open objects and subtreeStart the entries before the
+	// outermost one.
+	entries      int
+	objects      int
+	subtreeStart int
+	// totalObjects is the number of objects entered so far.
+	totalObjects int
+	// repeats counts the occurrences of the scalar values.
+	repeats map[string]int
+	// values counts the occurrences of the scalar values for their
+	// fraction of the scalars, the most repeated being topValue.
+	values   map[string]int
+	scalars  int
+	topValue string
+	// keyRepeats counts the occurrences of the property names.
+	keyRepeats map[string]int
+	// embedded holds the decoded string value checked for an
+	// embedded JSON.
+	embedded []byte
+	// arrayRank is the number of directly nested arrays
+	// ending at the current container.
+	arrayRank int
+	// leaves is the number of leaf elements of the directly nested
+	// arrays ending at the current container.
+	leaves int
+}
+
+// checkArrayLiteral checks the byte length of the array literal
+// whose content starts at start and which closes at end.
+func (s *state) checkArrayLiteral(start, end int) error {
+	// +2 for the brackets
+	if n := end - start + 2; s.verifier.arrayLiteralBytesEnabled &&
+		n > s.verifier.MaxArrayLiteralBytes {
+		return newLimitError(arrayLiteralBytes,
+			s.verifier.MaxArrayLiteralBytes, n, start-1)
+	}
+	return nil
+}
+
+// addEscapes adds the escape sequences of the valid string span
+// starting at offset start to the total escapes of the walk.
+func (s *state) addEscapes(data []byte, start, end int) error {
+	str := data[start+1 : end-1]
+	if bytes.IndexByte(str, '\\') < 0 {
+		return nil
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] == '\\' {
+			s.escapes++
+			// skip the escaped character
+			i++
+		}
+	}
+	if s.escapes > s.verifier.MaxTotalEscapes {
+		return newLimitError(totalEscapes, s.verifier.MaxTotalEscapes,
+			s.escapes, start)
+	}
+	return nil
+}
+
+// countFloat counts the valid number token at offset i if it has a
+// fraction or an exponent part.
+func (s *state) countFloat(token []byte, i int) error {
+	if bytes.IndexAny(token, ".eE") < 0 {
+		return nil
+	}
+	if s.floats++; s.floats > s.verifier.MaxFloatCount {
+		return newLimitError(floatCount, s.verifier.MaxFloatCount,
+			s.floats, i)
+	}
+	return nil
+}
+
+// addNumberBytes adds the n bytes of the number at offset i.
+func (s *state) addNumberBytes(n int, i int) error {
+	if s.numberBytes += n; s.numberBytes > s.verifier.MaxTotalNumberBytes {
+		return newLimitError(totalNumberBytes,
+			s.verifier.MaxTotalNumberBytes, s.numberBytes, i)
+	}
+	return nil
+}
+
+// approach calls the approach warning function if found reaches,
+// from prev, the approach fraction of max.
+func (s *state) approach(kind string, prev, found, max int) {
+	t := int(math.Ceil(s.verifier.approachFraction * float64(max)))
+	if prev < t && t <= found {
+		s.verifier.approachFn(kind, found, max)
+	}
+}
+
+// checkDepth checks the container depth limit at offset i within
+// a container of type t, zero if unknown.
+// Containers under a depth exempt key are not counted.
+func (s *state) checkDepth(i int, t Type) error {
+	d := s.depth - s.exemptDepth
+	if s.verifier.jsonContainerDepthEnabled && s.verifier.JSONContainerDepth < d {
+		return &LimitError{Kind: containerDepth,
+			Max: s.verifier.JSONContainerDepth, Found: d, Offset: i,
+			Container: t}
+	}
+	return nil
+}
+
+// handler receives the structural events of a verification walk,
+// an error returned by it stops the walk.
+type handler interface {
+	// begin is called with the opening byte c ({ or [) at offset i.
+	begin(c byte, i int) error
+	// end is called with the closing byte c (} or ]) at offset i.
+	end(c byte, i int) error
+	// key is called with the valid key string span, quotes included.
+	key(data []byte, startIndex, endIndex int) error
+	// value is called with the valid scalar value span.
+	value(data []byte, startIndex, endIndex int) error
+}
+
+// forwarder forwards the events of a walk to next if set, a handler
+// embeds it and overrides only the events it inspects.
+type forwarder struct {
+	next handler
+}
+
+func (f *forwarder) begin(c byte, i int) error {
+	if f.next != nil {
+		return f.next.begin(c, i)
+	}
+	return nil
+}
+
+func (f *forwarder) end(c byte, i int) error {
+	if f.next != nil {
+		return f.next.end(c, i)
+	}
+	return nil
+}
+
+func (f *forwarder) key(data []byte, startIndex, endIndex int) error {
+	if f.next != nil {
+		return f.next.key(data, startIndex, endIndex)
+	}
+	return nil
+}
+
+func (f *forwarder) value(data []byte, startIndex, endIndex int) error {
+	if f.next != nil {
+		return f.next.value(data, startIndex, endIndex)
+	}
+	return nil
+}
+
+// addMemory adds n bytes at offset i to the estimated decoded memory.
+func (s *state) addMemory(n, i int) error {
+	s.memory += n
+	if s.memory > s.verifier.MaxDecodedMemoryEstimate {
+		return newLimitError(decodedMemory,
+			s.verifier.MaxDecodedMemoryEstimate, s.memory, i)
+	}
+	return nil
+}
+
+// enterContainer counts the container opening c at offset i
+// and increments the depth.
+func (s *state) enterContainer(c byte, i int) error {
+	s.depth++
+	s.relaxPending = false
+	s.urlKey = ""
+	s.format = formatRule{}
+	s.epoch = nil
+	s.arrayOverride = false
+	s.objectOverride = false
+	if s.exemptPending || s.exemptDepth > 0 {
+		s.exemptPending = false
+		s.exemptDepth++
+	}
+	maxInt(&s.maxDepth, s.depth)
+	if s.verifier.approachFn != nil && s.exemptDepth == 0 &&
+		s.verifier.jsonContainerDepthEnabled {
+		s.approach(containerDepth, s.depth-1, s.depth,
+			s.verifier.JSONContainerDepth)
+	}
+	s.containers++
+	if s.verifier.containerCountEnabled &&
+		s.containers > s.verifier.MaxContainerCount {
+		return newLimitError(containerCount,
+			s.verifier.MaxContainerCount, s.containers, i)
+	}
+	if s.verifier.arrayRankEnabled && c == '[' &&
+		s.arrayRank > s.verifier.MaxArrayRank {
+		return newLimitError(arrayRank, s.verifier.MaxArrayRank,
+			s.arrayRank, i)
+	}
+	if s.verifier.structuralStatesEnabled {
+		key := s.depth << 1
+		if c == '[' {
+			key |= 1
+		}
+		for key/64 >= len(s.states) {
+			s.states = append(s.states, 0)
+		}
+		if bit := uint64(1) << (key % 64); s.states[key/64]&bit == 0 {
+			s.states[key/64] |= bit
+			s.statesCount++
+			if s.statesCount > s.verifier.MaxStructuralStates {
+				return newLimitError(structuralStates,
+					s.verifier.MaxStructuralStates, s.statesCount, i)
+			}
+		}
+	}
+	if s.h != nil {
+		return s.h.begin(c, i)
+	}
+	return nil
+}
+
+// checkBytesPerChild checks the bytes per child of the non empty
+// container f closing at offset i.
+func (s *state) checkBytesPerChild(f *frame, i int) error {
+	// from the opening to the closing byte
+	n := (i - f.start + 2) / f.count
+	if n <= s.verifier.MaxBytesPerChild {
+		return nil
+	}
+	t := TypeObject
+	if f.c == '[' {
+		t = TypeArray
+	}
+	return fmt.Errorf("jtp.maxBytesPerChildReached.Container-[%s]."+
+		"Max-[%d]-Allowed.Found-[%d]", t, s.verifier.MaxBytesPerChild, n)
+}
+
+// countOpen counts the container opened at offset i, see
+// WithMaxConsecutiveOpens.
+func (s *state) countOpen(i int) error {
+	s.opens++
+	if s.opens > s.verifier.MaxConsecutiveOpens {
+		return newLimitError(consecutiveOpens,
+			s.verifier.MaxConsecutiveOpens, s.opens, i)
+	}
+	return nil
+}
+
+// countTransition counts the container c at offset i if entered
+// within a container of the other type, the last of the frames.
+func (s *state) countTransition(frames []frame, c byte, i int) error {
+	if len(frames) == 0 || frames[len(frames)-1].c == c {
+		return nil
+	}
+	s.transitions++
+	if s.transitions > s.verifier.MaxStructureTransitions {
+		return newLimitError(transitions, s.verifier.MaxStructureTransitions,
+			s.transitions, i)
+	}
+	return nil
+}
+
+// leaveContainer decrements the depth on the container closing c
+// at offset i.
+func (s *state) leaveContainer(c byte, i int) error {
+	s.depth--
+	s.opens = 0
+	if s.exemptDepth > 0 {
+		s.exemptDepth--
+	}
+	if s.h != nil {
+		return s.h.end(c, i)
+	}
+	return nil
+}
+
+// repeat counts an occurrence of the scalar value at offset i,
+// at most maxTrackedValues distinct values are counted.
+func (s *state) repeat(value []byte, i int) error {
+	if s.repeats == nil {
+		s.repeats = make(map[string]int)
+	}
+	n, seen := s.repeats[string(value)]
+	if !seen && len(s.repeats) == maxTrackedValues {
+		return nil
+	}
+	n++
+	s.repeats[string(value)] = n
+	if n > s.verifier.MaxIdenticalValueRepeats {
+		return newLimitError(identicalRepeats,
+			s.verifier.MaxIdenticalValueRepeats, n, i)
+	}
+	return nil
+}
+
+// countValue counts an occurrence of the scalar value, at most
+// maxTrackedValues distinct values are counted.
+func (s *state) countValue(value []byte) {
+	s.scalars++
+	if len(value) > s.verifier.TrackedValueLength {
+		return
+	}
+	if s.values == nil {
+		s.values = make(map[string]int)
+	}
+	n, seen := s.values[string(value)]
+	if !seen && len(s.values) == maxTrackedValues {
+		return
+	}
+	s.values[string(value)] = n + 1
+	if n+1 > s.values[s.topValue] {
+		s.topValue = string(value)
+	}
+}
+
+// checkValueFraction checks the fraction of the scalar values which
+// are the most repeated one.
+func (s *state) checkValueFraction() error {
+	if s.scalars < minFractionValues {
+		return nil
+	}
+	frac := float64(s.values[s.topValue]) / float64(s.scalars)
+	if frac > s.verifier.MaxValueRepetitionFraction {
+		return fmt.Errorf("jtp.valueRepetitionFractionExceeded.Value-[%s]",
+			s.topValue)
+	}
+	return nil
+}
+
+// repeatKey counts an occurrence of the property name, as written
+// without its double quotes, at offset i, at most maxTrackedValues
+// distinct names are counted.
+func (s *state) repeatKey(name []byte, i int) error {
+	if s.keyRepeats == nil {
+		s.keyRepeats = make(map[string]int)
+	}
+	n, seen := s.keyRepeats[string(name)]
+	if !seen && len(s.keyRepeats) == maxTrackedValues {
+		return nil
+	}
+	n++
+	s.keyRepeats[string(name)] = n
+	if n > s.verifier.MaxGlobalKeyRepeats {
+		return fmt.Errorf("jtp.maxGlobalKeyRepeatsReached.Key-[%s]", name)
+	}
+	return nil
+}
+
+// scalar emits the valid scalar value data[i:outi] to the handler.
+func (s *state) scalar(data []byte, i, outi int, ok bool,
+	err error) (int, bool, error) {
+	s.opens = 0
+	if ok && err == nil && s.verifier.tokenBytesEnabled &&
+		outi-i > s.verifier.MaxTokenBytes {
+		return i, false, newLimitError(tokenBytes,
+			s.verifier.MaxTokenBytes, outi-i, i)
+	}
+	if ok && err == nil && s.verifier.identicalRepeatsEnabled &&
+		outi-i <= s.verifier.TrackedValueLength {
+		if err = s.repeat(data[i:outi], i); err != nil {
+			return i, false, err
+		}
+	}
+	if ok && err == nil && s.verifier.valueFractionEnabled {
+		s.countValue(data[i:outi])
+	}
+	if ok && err == nil && s.h != nil {
+		if err = s.h.value(data, i, outi); err != nil {
+			return i, false, err
+		}
+	}
+	return outi, ok, err
+}
+
+// frame is an open container of a walk.
+type frame struct {
+	// c is the opening byte, { or [.
+	c byte
+	// start is the offset of the container content.
+	start int
+	// elem is the type of the elements of an homogeneous array,
+	// zero until the first non null element.
+	elem Type
+	// count is the number of elements or entries so far, units the
+	// array unit budget used and leading the scalars before the first
+	// container of an array, -1 once a container is seen.
+	count   int
+	units   int
+	leading int
+	// maxCount overrides the element or entry count limit
+	// if overridden, zero for none.
+	maxCount   int
+	overridden bool
+	// rank is the array rank restored when leaving a container
+	// opened by the walk, and leaves the leaf elements count
+	// restored when leaving the first of directly nested arrays.
+	rank   int
+	leaves int
+	opened bool
+	// table is set for an array whose first element is an object,
+	// cols is the largest entry count of its objects so far.
+	table bool
+	cols  int
+}
+
+// stackFrames is the number of frames of a walk kept on the
+// goroutine stack, a deeper JSON grows them on the heap.
+const stackFrames = 32
+
+// the steps of a walk.
+const (
+	// a value at i
+	stepValue = iota
+	// the content of the container opened at i-1
+	stepOpen
+	// an array element at i
+	stepElement
+	// an object key at i
+	stepKey
+	// after a value ending at i
+	stepNext
+	// the container closing at i
+	stepClose
+)
+
+func isValidArray(data []byte, i int, s *state) (outi int, ok bool, err error) {
+	return walk(data, i, s, '[')
+}
+
+func isValidObject(data []byte, i int, s *state) (outi int, ok bool, err error) {
+	return walk(data, i, s, '{')
+}
+
+func validany(data []byte, i int, s *state) (outi int, ok bool, err error) {
+	return walk(data, i, s, 0)
+}
+
+// walk validates the value at i, or if open is { or [ the content of
+// the container opened at i-1, as a loop over an explicit stack of the
+// open containers, so the nesting is bounded by the limits and the
+// heap, not by the goroutine stack.
+func walk(data []byte, i int, s *state, open byte) (outi int, ok bool, err error) {
+	var stack [stackFrames]frame
+	frames := stack[:0]
+	step := stepValue
+	// comma is the offset of the last comma between two values.
+	comma := 0
+	if open != 0 {
+		frames = append(frames, frame{c: open, start: i})
+		step = stepOpen
+	}
+	for {
+		if s.verifier.scanBudgetEnabled {
+			if err = s.checkScanBudget(i); err != nil {
+				return i, false, err
+			}
+		}
+		switch step {
+		case stepValue:
+			if s.ctx != nil {
+				s.steps++
+				if s.steps%checkpointInterval == 0 {
+					if err = s.ctx.Err(); err != nil {
+						return i, false, err
+					}
+				}
+			}
+			if err = s.checkDepth(i, 0); err != nil {
+				return i, false, err
+			}
+			if i = skipSpace(data, i); i == len(data) {
+				return i, false, err
+			}
+			switch data[i] {
+			default:
+				if len(frames) > 0 && frames[len(frames)-1].c == '{' &&
+					(data[i] == ',' || data[i] == '}') {
+					return i, false, &MalformedError{Reason: emptyObjectValue,
+						Offset: i}
+				}
+				return i, false, err
+			case '{':
+				rank := s.arrayRank
+				s.arrayRank = 0
+				if s.objects == 0 {
+					s.subtreeStart = s.entries
+				}
+				s.objects++
+				maxCount, overridden := s.objectMax, s.objectOverride
+				if s.verifier.tokenGapEnabled {
+					if err = s.checkTokenGap(i); err != nil {
+						return i, false, err
+					}
+				}
+				if s.verifier.consecutiveOpensEnabled {
+					if err = s.countOpen(i); err != nil {
+						return i, false, err
+					}
+				}
+				if s.verifier.transitionsEnabled {
+					if err = s.countTransition(frames, '{', i); err != nil {
+						return i, false, err
+					}
+				}
+				if s.verifier.totalObjectsEnabled ||
+					s.verifier.objectFillEnabled {
+					s.totalObjects++
+					if s.verifier.totalObjectsEnabled &&
+						s.totalObjects > s.verifier.MaxTotalObjects {
+						return i, false, newLimitError(totalObjects,
+							s.verifier.MaxTotalObjects, s.totalObjects, i)
+					}
+				}
+				if err = s.enterContainer(data[i], i); err != nil {
+					return i, false, err
+				}
+				i++
+				frames = append(frames, frame{c: '{', start: i, rank: rank,
+					opened: true, maxCount: maxCount, overridden: overridden})
+				step = stepOpen
+				continue
+			case '[':
+				rank := s.arrayRank
+				s.arrayRank++
+				leaves := s.leaves
+				if rank == 0 {
+					s.leaves = 0
+				}
+				maxCount, overridden := s.arrayMax, s.arrayOverride
+				if s.verifier.tokenGapEnabled {
+					if err = s.checkTokenGap(i); err != nil {
+						return i, false, err
+					}
+				}
+				if s.verifier.consecutiveOpensEnabled {
+					if err = s.countOpen(i); err != nil {
+						return i, false, err
+					}
+				}
+				if s.verifier.transitionsEnabled {
+					if err = s.countTransition(frames, '[', i); err != nil {
+						return i, false, err
+					}
+				}
+				if err = s.enterContainer(data[i], i); err != nil {
+					return i, false, err
+				}
+				i++
+				frames = append(frames, frame{c: '[', start: i, rank: rank,
+					leaves: leaves, opened: true, maxCount: maxCount,
+					overridden: overridden})
+				step = stepOpen
+				continue
+			case '"':
+				// validate string
+				if s.verifier.SkipStringValidation {
+					outi, ok = skipString(data, i+1)
+				} else {
+					outi, ok = isValidateString(data, i+1,
+						s.verifier.TolerateRawControlInStrings)
+				}
+				if !ok {
+					return outi, false, err
+				}
+				if s.observed != nil {
+					s.observed.stringValue(data, i, outi)
+				}
+				if s.verifier.scanBudgetEnabled {
+					s.rescanned += (outi - i) * s.stringRescans(false)
+				}
+				maxLen, lenEnabled := s.verifier.stringLengthLimit(s.depth)
+				err = validateStringLength(data, i, outi,
+					lenEnabled && !s.relaxPending,
+					maxLen, stringValueLength, decodedRuneCount)
+				if err == nil && s.verifier.approachFn != nil &&
+					lenEnabled && !s.relaxPending {
+					s.approach(stringValueLength, 0,
+						decodedRuneCount(data, i, outi), maxLen)
+				}
+				if err == nil {
+					err = validateStringLength(data, i, outi,
+						s.verifier.stringUTF16UnitsEnabled,
+						s.verifier.StringValueUTF16Units, stringUTF16Units,
+						decodedUTF16Units)
+				}
+				if err == nil {
+					// ASCII runes weigh one byte and multibyte runes
+					// their UTF-8 encoded length, once decoded
+					err = validateStringLength(data, i, outi,
+						s.verifier.stringWeightEnabled,
+						s.verifier.StringValueWeight, stringValueWeight,
+						decodedByteLength)
+				}
+				if err == nil && s.verifier.totalEscapesEnabled {
+					err = s.addEscapes(data, i, outi)
+				}
+				if err == nil && s.verifier.decodedMemoryEnabled {
+					// -2 for double quote
+					err = s.addMemory(outi-i-2, i)
+				}
+				if err == nil && s.verifier.stringContentEnabled(false) {
+					err = validateStringContent(data, i, outi, false,
+						&s.verifier)
+				}
+				if err == nil && s.urlKey != "" {
+					err = validateURL(data, i, outi, s.urlKey,
+						s.verifier.RequireHTTPSURLs)
+				}
+				if err == nil && s.format.format != 0 {
+					err = validateFormat(data, i, outi, s.format)
+				}
+				if err == nil && s.verifier.embeddedJSONEnabled {
+					err = s.checkEmbeddedJSON(data, i, outi)
+				}
+			case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				if s.verifier.SkipNumberValidation &&
+					!s.verifier.numberContentEnabled() {
+					outi, ok = skipNumber(data, i+1), true
+				} else {
+					outi, ok = isValidNumber(data, i+1)
+				}
+				if ok && s.verifier.numberContentEnabled() {
+					err = validateNumberContent(data[i:outi], i, &s.verifier)
+				}
+				if ok && err == nil && s.epoch != nil {
+					err = validateEpoch(data[i:outi], s.epoch)
+				}
+				if ok && err == nil && s.verifier.floatCountEnabled {
+					err = s.countFloat(data[i:outi], i)
+				}
+				if ok && err == nil && s.verifier.totalNumberBytesEnabled {
+					err = s.addNumberBytes(outi-i, i)
+				}
+			case 't':
+				outi, ok = isValidTrue(data, i+1)
+				if ok && s.verifier.trueCountEnabled {
+					if s.trues++; s.trues > s.verifier.MaxTrueCount {
+						err = newLimitError(trueCount,
+							s.verifier.MaxTrueCount, s.trues, i)
+					}
+				}
+			case 'f':
+				outi, ok = isValidFalse(data, i+1)
+			case 'n':
+				outi, ok = isValidNull(data, i+1)
+				if ok && s.verifier.DisallowNull {
+					err = fmt.Errorf("jtp.nullValueNotAllowed.Offset-[%d]", i)
+				}
+			}
+			if s.verifier.emptyValuesEnabled && len(frames) > 0 && ok &&
+				err == nil && (data[i] == 'n' || outi-i == 2 && data[i] == '"') {
+				err = s.addEmptyValue(i)
+			}
+			if i, ok, err = s.scalar(data, i, outi, ok, err); !ok || err != nil {
+				return i, false, err
+			}
+			step = stepNext
+
+		case stepOpen:
+			f := &frames[len(frames)-1]
+			t := TypeObject
+			if f.c == '[' {
+				t = TypeArray
+			}
+			if err = s.checkDepth(i, t); err != nil {
+				return i, false, err
+			}
+			if i = skipSpace(data, i); i == len(data) {
+				return i, false, err
+			}
+			switch {
+			case data[i] == ']' && f.c == '[', data[i] == '}' && f.c == '{':
+				step = stepClose
+			case f.c == '[':
+				step = stepElement
+			case data[i] == '"':
+				step = stepKey
+			default:
+				return i, false, err
+			}
+
+		case stepElement:
+			f := &frames[len(frames)-1]
+			// offset of the element
+			i = skipSpace(data, i)
+			if i < len(data) && data[i] == ',' {
+				return i, false, &MalformedError{Reason: emptyArrayElement,
+					Offset: i}
+			}
+			if i < len(data) && data[i] == ']' {
+				// only after a comma, ] just after [ closes the array
+				return i, false, &MalformedError{Reason: trailingComma,
+					Offset: comma}
+			}
+			// count the element before validating its content,
+			// so an overflowing element is never walked.
+			f.count++
+			if s.observed != nil {
+				maxInt(&s.observed.arrayElements, f.count)
+			}
+			maxElements := s.verifier.MaxArrayElementCount
+			limitElements := s.verifier.arrayEntryCountEnabled
+			if f.overridden {
+				maxElements, limitElements = f.maxCount, f.maxCount > 0
+			}
+			if limitElements && f.count > maxElements {
+				return i, false, newLimitError(arrayElementCount,
+					maxElements, f.count, i)
+			}
+			if s.verifier.approachFn != nil && limitElements {
+				s.approach(arrayElementCount, f.count-1, f.count,
+					maxElements)
+			}
+			if s.verifier.leadingScalarsEnabled && f.leading >= 0 &&
+				i < len(data) {
+				if data[i] != '{' && data[i] != '[' {
+					f.leading++
+				} else if f.leading > s.verifier.MaxLeadingScalars {
+					return i, false, newLimitError(leadingScalars,
+						s.verifier.MaxLeadingScalars, f.leading, i)
+				} else {
+					// first container seen
+					f.leading = -1
+				}
+			}
+			if s.verifier.tableCellsEnabled && i < len(data) {
+				if f.count == 1 && data[i] == '{' {
+					f.table = true
+				}
+				if err = s.checkTableCells(f, i); err != nil {
+					return i, false, err
+				}
+			}
+			if s.verifier.flattenedElementsEnabled && i < len(data) &&
+				data[i] != '[' {
+				s.leaves++
+				if s.leaves > s.verifier.MaxFlattenedArrayElements {
+					return i, false, newLimitError(flattenedElements,
+						s.verifier.MaxFlattenedArrayElements, s.leaves, i)
+				}
+			}
+			if s.verifier.HomogeneousArrays && i < len(data) {
+				if err = s.checkElementType(f, data[i]); err != nil {
+					return i, false, err
+				}
+			}
+			if s.verifier.arrayUnitBudgetEnabled {
+				f.units++
+				if i < len(data) && data[i] == '{' {
+					f.units += s.verifier.ArrayObjectWeight - 1
+				}
+				if f.units > s.verifier.ArrayUnitBudget {
+					return i, false, newLimitError(arrayUnitBudget,
+						s.verifier.ArrayUnitBudget, f.units, i)
+				}
+			}
+			if s.verifier.decodedMemoryEnabled {
+				if err = s.addMemory(s.verifier.DecodedMemoryCosts.
+					ArrayElement, i); err != nil {
+					return i, false, err
+				}
+			}
+			// can contain Any value
+			step = stepValue
+
+		case stepKey:
+			f := &frames[len(frames)-1]
+			// key should be string
+			tempI := i // for string length
+			var runes int
+			i, runes, ok = isValidateKey(data, i+1)
+			if !ok {
+				return i, false, err
+			}
+			f.count++
+			if s.observed != nil {
+				s.observed.key(runes, f.count, s.depth)
+			}
+			// check for entries count
+			maxEntries := s.verifier.ObjectEntryCount
+			limitEntries := s.verifier.objectEntryCountEnabled
+			if f.overridden {
+				maxEntries, limitEntries = f.maxCount, f.maxCount > 0
+			}
+			if limitEntries && maxEntries < f.count {
+				return i, false, newLimitError(objectEntryCount,
+					maxEntries, f.count, tempI)
+			}
+			if s.verifier.approachFn != nil && limitEntries {
+				s.approach(objectEntryCount, f.count-1, f.count, maxEntries)
+			}
+			s.entries++
+			if s.verifier.subtreeEntriesEnabled {
+				// the outermost open object has the largest subtree
+				if n := s.entries - s.subtreeStart; n > s.verifier.MaxSubtreeEntries {
+					return i, false, newLimitError(subtreeEntries,
+						s.verifier.MaxSubtreeEntries, n, tempI)
+				}
+			}
+			if s.verifier.cumulativeEntriesEnabled &&
+				s.entries > s.verifier.CumulativeMaxEntries {
+				return i, false, newLimitError(cumulativeEntries,
+					s.verifier.CumulativeMaxEntries, s.entries, tempI)
+			}
+			if err = s.checkKey(data, tempI, i, runes); err != nil {
+				return i, false, err
+			}
+			// key should be followed by :
+			keyEnd := i
+			var ws int
+			if i, ok, ws = isValidColon(data, i); !ok {
+				return i, false, err
+			}
+			if s.verifier.colonWhitespaceEnabled &&
+				ws > s.verifier.MaxColonWhitespace {
+				return i, false, newLimitError(colonWhitespace,
+					s.verifier.MaxColonWhitespace, ws, keyEnd)
+			}
+			if s.verifier.tokenGapEnabled {
+				colon := keyEnd + bytes.IndexByte(data[keyEnd:i], ':')
+				if err = s.checkTokenGap(colon); err != nil {
+					return i, false, err
 				}
 			}
-		} else if data[i] == '"' {
-			return i + 1, true
-		}
-	}
-	return i, false
-}
+			// followed by Any Value
+			step = stepValue
 
-func isValidArray(data []byte, i int, depth *int,
-	verifier *Verify) (outi int, ok bool, err error) {
-	if verifier.jsonContainerDepthEnabled && verifier.JSONContainerDepth < *depth {
-		return i, false,
-			fmt.Errorf("jtp.maxContainerDepthReached.Max-[%d]-Allowed."+
-				"Found-[%d]",
-				verifier.JSONContainerDepth, *depth)
-	}
-	for ; i < len(data); i++ {
-		child := 0
-		switch data[i] {
-		default:
-			for ; i < len(data); i++ {
-				// can contain Any value
-				if i, ok, err = validany(data, i, depth, verifier); !ok {
+		case stepNext:
+			if len(frames) == 0 {
+				return i, true, err
+			}
+			f := &frames[len(frames)-1]
+			if f.c == '[' {
+				if i, ok = isValidComma(data, i, ']'); !ok {
 					return i, false, err
 				}
-				// children
-				i, ok = isValidComma(data, i, ']')
-				if !ok {
-					return i, false, err
+				if data[i] == ']' {
+					step = stepClose
+					continue
 				}
-				child++
-				if verifier.arrayEntryCountEnabled && child > verifier.MaxArrayElementCount {
-					return i, false,
-						fmt.Errorf(
-							"jtp.maxArrayElementCountReached."+
-								"Max-[%d]-Allowed.Found-[%d]",
-							verifier.MaxArrayElementCount, child)
+				comma = i
+				if s.verifier.tokenGapEnabled {
+					if err = s.checkTokenGap(i); err != nil {
+						return i, false, err
+					}
 				}
-				if data[i] == ']' {
-					*depth--
-					return i + 1, true, err
+				if i++; i == len(data) {
+					return i, false, err
 				}
+				step = stepElement
+				continue
 			}
-		case ' ', '\t', '\n', '\r':
-			continue
-		case ']':
-			*depth--
-			return i + 1, true, err
-		}
-	}
-	return i, false, err
-}
-
-func isValidObject(data []byte, i int, depth *int,
-	verifier *Verify) (outi int, ok bool, err error) {
-	if verifier.jsonContainerDepthEnabled && verifier.JSONContainerDepth < *depth {
-		return i, false,
-			fmt.Errorf("jtp.maxContainerDepthReached.Max-[%d]-Allowed."+
-				"Found-[%d]",
-				verifier.JSONContainerDepth, *depth)
-	}
-	for ; i < len(data); i++ {
-		switch data[i] {
-		default:
-			return i, false, err
-		case ' ', '\t', '\n', '\r':
-			continue
-		case '}':
-			*depth--
-			return i + 1, true, err
-		case '"':
-			// entries
-			entries := 0
-		key:
-			// key should be string
-			tempI := i // for string length
-			i, ok = isValidateString(data, i+1)
-			if !ok {
+			s.exemptPending = false
+			s.relaxPending = false
+			s.urlKey = ""
+			s.format = formatRule{}
+			s.epoch = nil
+			s.arrayOverride = false
+			s.objectOverride = false
+			if i, ok = isValidComma(data, i, '}'); !ok {
 				return i, false, err
 			}
-			entries++
-
-			// check for entries count
-			if verifier.objectEntryCountEnabled && verifier.
-				ObjectEntryCount < entries {
-				return i, false,
-					fmt.Errorf("jtp.maxObjectEntryCountReached."+
-						"Max-[%d]-Allowed.Found-[%d]",
-						verifier.ObjectEntryCount, entries)
-			}
-
-			if ok {
-				// validate key length
-				err = validateStringLength(data, tempI, i,
-					verifier.objectKeyLengthEnabled,
-					verifier.ObjectKeyLength, objectKeyValueLength)
-				if err != nil {
-					// no further json verification done
+			if data[i] == '}' {
+				step = stepClose
+				continue
+			}
+			comma = i
+			if s.verifier.tokenGapEnabled {
+				if err = s.checkTokenGap(i); err != nil {
 					return i, false, err
 				}
 			}
-
-			// key should be followed by :
-			if i, ok = isValidColon(data, i); !ok {
-				return i, false, err
-			}
-			// followed by Any Value
-			if i, ok, err = validany(data, i, depth,
-				verifier); !ok || err != nil {
+			if i = skipSpace(data, i+1); i == len(data) || data[i] != '"' {
+				if i < len(data) && data[i] == '}' {
+					return i, false, &MalformedError{Reason: trailingComma,
+						Offset: comma}
+				}
 				return i, false, err
 			}
+			step = stepKey
 
-			if i, ok = isValidComma(data, i, '}'); !ok {
+		case stepClose:
+			f := frames[len(frames)-1]
+			if s.verifier.tokenGapEnabled {
+				if err = s.checkTokenGap(i); err != nil {
+					return i, false, err
+				}
+			}
+			if f.c == '[' {
+				if err = s.checkArrayLiteral(f.start, i); err != nil {
+					return i, false, err
+				}
+			}
+			if s.verifier.bytesPerChildEnabled && f.count > 0 {
+				if err = s.checkBytesPerChild(&f, i); err != nil {
+					return i, false, err
+				}
+			}
+			if err = s.leaveContainer(data[i], i); err != nil {
 				return i, false, err
 			}
-			if data[i] == '}' {
-				*depth--
-				return i + 1, true, err
+			frames = frames[:len(frames)-1]
+			if s.verifier.tableCellsEnabled && f.c == '{' && len(frames) > 0 &&
+				frames[len(frames)-1].table {
+				p := &frames[len(frames)-1]
+				maxInt(&p.cols, f.count)
+				if err = s.checkTableCells(p, i); err != nil {
+					return i, false, err
+				}
 			}
-			i++
-			for ; i < len(data); i++ {
-				switch data[i] {
-				default:
+			if s.verifier.TopLevelMustBeNonEmpty && s.depth == 0 &&
+				f.count == 0 {
+				return i, false, ErrEmptyTopLevelContainer
+			}
+			if s.verifier.emptyValuesEnabled && len(frames) > 0 &&
+				f.count == 0 {
+				if err = s.addEmptyValue(f.start - 1); err != nil {
 					return i, false, err
-				case ' ', '\t', '\n', '\r':
-					continue
-				case '"':
-					goto key
 				}
 			}
-			return i, false, err
+			if f.opened {
+				s.arrayRank = f.rank
+				if f.c == '[' && f.rank == 0 {
+					s.leaves = f.leaves
+				}
+				if f.c == '{' {
+					s.objects--
+				}
+			}
+			i++
+			if len(frames) == 0 {
+				return i, true, err
+			}
+			step = stepNext
 		}
 	}
-	return i, false, err
 }
 
-func validany(data []byte, i int, depth *int,
-	verifier *Verify) (outi int, ok bool, err error) {
-	if verifier.jsonContainerDepthEnabled && verifier.JSONContainerDepth < *depth {
-		return i, false,
-			fmt.Errorf("jtp.maxContainerDepthReached.Max-[%d]-Allowed."+
-				"Found-[%d]",
-				verifier.JSONContainerDepth, *depth)
+// checkTableCells checks the cells of the array f at offset i.
+func (s *state) checkTableCells(f *frame, i int) error {
+	if n := f.count * f.cols; f.table && n > s.verifier.MaxTableCells {
+		return newLimitError(tableCells, s.verifier.MaxTableCells, n, i)
 	}
-	for ; i < len(data); i++ {
-		switch data[i] {
-		default:
-			return i, false, err
-		case ' ', '\t', '\n', '\r':
-			continue
-		case '{':
-			*depth++
-			return isValidObject(data, i+1, depth, verifier)
-		case '[':
-			*depth++
-			return isValidArray(data, i+1, depth, verifier)
-		case '"':
-			// validate string
-			outi, ok = isValidateString(data, i+1)
-			err = validateStringLength(data, i, outi,
-				verifier.stringLenEnabled,
-				verifier.StringValueLen, stringValueLength)
-			return
-		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			outi, ok = isValidNumber(data, i+1)
-			return
-		case 't':
-			outi, ok = isValidTrue(data, i+1)
-			return
-		case 'f':
-			outi, ok = isValidFalse(data, i+1)
-		case 'n':
-			outi, ok = isValidNull(data, i+1)
-			return
-		}
-	}
-	return i, false, err
+	return nil
+}
+
+// checkRequiredKeys checks the required top level keys were seen
+// in the top level value starting with c.
+func (s *state) checkRequiredKeys(c byte) error {
+	for j, key := range s.verifier.RequiredTopLevelKeys {
+		if c != '{' || !s.requiredSeen.has(j) {
+			return fmt.Errorf("jtp.missingRequiredKey.Key-[%s]", key)
+		}
+	}
+	return nil
+}
+
+// bitset is a set of small non negative integers, growing as needed.
+type bitset []uint64
+
+func (b *bitset) add(i int) {
+	for i/64 >= len(*b) {
+		*b = append(*b, 0)
+	}
+	(*b)[i/64] |= 1 << uint(i%64)
+}
+
+func (b bitset) has(i int) bool {
+	return i/64 < len(b) && b[i/64]&(1<<uint(i%64)) != 0
+}
+
+// checkTokenGap checks the bytes between the previous structural
+// token and the one at offset i.
+func (s *state) checkTokenGap(i int) error {
+	if gap := i - s.tokenEnd; gap > s.verifier.MaxTokenGap {
+		return newLimitError(tokenGap, s.verifier.MaxTokenGap, gap, i)
+	}
+	s.tokenEnd = i + 1
+	return nil
+}
+
+// checkScanBudget checks the scan budget with the walk at offset i.
+func (s *state) checkScanBudget(i int) error {
+	if n := i + s.rescanned; n > s.verifier.MaxScanBudget {
+		return newLimitError(scanBudget, s.verifier.MaxScanBudget, n, i)
+	}
+	return nil
+}
+
+// stringRescans returns the number of enabled checks rescanning
+// a string key (isKey) or value.
+func (s *state) stringRescans(isKey bool) (n int) {
+	var checks [3]bool
+	if isKey {
+		checks = [3]bool{s.verifier.stringContentEnabled(true),
+			s.verifier.keyByteLengthEnabled, s.verifier.keyUTF16UnitsEnabled}
+	} else {
+		_, lenEnabled := s.verifier.stringLengthLimit(s.depth)
+		checks = [3]bool{s.verifier.stringContentEnabled(false),
+			lenEnabled && !s.relaxPending, s.verifier.stringUTF16UnitsEnabled}
+		if s.urlKey != "" {
+			n++
+		}
+		if s.format.format != 0 {
+			n++
+		}
+	}
+	for _, enabled := range checks {
+		if enabled {
+			n++
+		}
+	}
+	return
+}
+
+// addEmptyValue counts the empty value at offset i.
+func (s *state) addEmptyValue(i int) error {
+	s.emptyValues++
+	if s.emptyValues > s.verifier.MaxEmptyValues {
+		return newLimitError(emptyValues, s.verifier.MaxEmptyValues,
+			s.emptyValues, i)
+	}
+	return nil
+}
+
+// checkElementType checks that the array element starting with the
+// byte c has the type of the previous elements of the array f.
+func (s *state) checkElementType(f *frame, c byte) error {
+	t := valueType(c)
+	switch {
+	case t == TypeNull && s.verifier.NullsInHomogeneousArrays:
+	case f.elem == 0:
+		f.elem = t
+	case t != f.elem:
+		return fmt.Errorf("jtp.heterogeneousArray.Expected-[%s].Got-[%s]",
+			f.elem, t)
+	}
+	return nil
+}
+
+// checkKey applies the key checks to the valid key span data[start:end]
+// of runes decoded code points, and records it as the current key.
+func (s *state) checkKey(data []byte, start, end, runes int) (err error) {
+	if s.verifier.scanBudgetEnabled {
+		s.rescanned += (end - start) * s.stringRescans(true)
+	}
+	if s.verifier.tokenBytesEnabled && end-start > s.verifier.MaxTokenBytes {
+		return newLimitError(tokenBytes, s.verifier.MaxTokenBytes,
+			end-start, start)
+	}
+	// -2 for double quote
+	if s.verifier.keyRepeatsEnabled &&
+		end-start-2 <= s.verifier.TrackedKeyLength {
+		if err = s.repeatKey(data[start+1:end-1], start); err != nil {
+			return err
+		}
+	}
+	// validate key length
+	maxLen, lenEnabled := s.verifier.keyLengthLimit(s.depth)
+	if lenEnabled && runes > maxLen {
+		// no further json verification done
+		return newLimitError(objectKeyValueLength, maxLen, runes, start)
+	}
+	if s.verifier.approachFn != nil && lenEnabled {
+		s.approach(objectKeyValueLength, 0, runes, maxLen)
+	}
+	err = validateStringLength(data, start, end,
+		s.verifier.keyByteLengthEnabled,
+		s.verifier.ObjectKeyByteLength, keyByteLength,
+		decodedByteLength)
+	if err != nil {
+		return err
+	}
+	err = validateStringLength(data, start, end,
+		s.verifier.keyUTF16UnitsEnabled,
+		s.verifier.ObjectKeyUTF16Units, keyUTF16Units,
+		decodedUTF16Units)
+	if err != nil {
+		return err
+	}
+	if s.verifier.totalEscapesEnabled {
+		if err = s.addEscapes(data, start, end); err != nil {
+			return err
+		}
+	}
+	if s.verifier.totalKeyBytesEnabled {
+		// -2 for double quote
+		s.keyBytes += end - start - 2
+		if s.keyBytes > s.verifier.MaxTotalKeyBytes {
+			return newLimitError(totalKeyBytes,
+				s.verifier.MaxTotalKeyBytes, s.keyBytes, start)
+		}
+	}
+	if s.depth == 1 && s.verifier.topLevelKeyLengthEnabled &&
+		runes > s.verifier.TopLevelKeyLength {
+		return newLimitError(topLevelKeyLength,
+			s.verifier.TopLevelKeyLength, runes, start)
+	}
+	if s.verifier.stringContentEnabled(true) {
+		err = validateStringContent(data, start, end, true,
+			&s.verifier)
+		if err != nil {
+			return err
+		}
+	}
+	if s.verifier.decodedMemoryEnabled {
+		// -2 for double quote
+		err = s.addMemory(s.verifier.DecodedMemoryCosts.
+			ObjectEntry+end-start-2, start)
+		if err != nil {
+			return err
+		}
+	}
+	if s.h != nil {
+		if err = s.h.key(data, start, end); err != nil {
+			return err
+		}
+	}
+	if s.exemptDepth == 0 && len(s.verifier.DepthExemptKeys) > 0 {
+		s.exemptPending = stringIn(data, start, end,
+			s.verifier.DepthExemptKeys)
+	}
+	if len(s.verifier.RelaxedStringLengthKeys) > 0 {
+		s.relaxPending = stringIn(data, start, end,
+			s.verifier.RelaxedStringLengthKeys)
+	}
+	if len(s.verifier.ArrayLimitOverrides) > 0 {
+		s.arrayMax, s.arrayOverride = keyLimit(
+			s.verifier.ArrayLimitOverrides, data, start, end)
+	}
+	if len(s.verifier.ObjectEntryLimitOverrides) > 0 {
+		s.objectMax, s.objectOverride = keyLimit(
+			s.verifier.ObjectEntryLimitOverrides, data, start, end)
+	}
+	if s.depth == 1 && s.verifier.allowedTopLevelKeysEnabled &&
+		!stringIn(data, start, end, s.verifier.AllowedTopLevelKeys) {
+		return fmt.Errorf("jtp.unexpectedTopLevelKey.Key-[%s]",
+			appendDecodedString(nil, data, start, end))
+	}
+	if s.depth == 1 && s.verifier.streamTopLevelKeysEnabled {
+		if err = s.addStreamKey(data, start, end); err != nil {
+			return err
+		}
+	}
+	if s.depth == 1 && len(s.verifier.RequiredTopLevelKeys) > 0 {
+		for j, key := range s.verifier.RequiredTopLevelKeys {
+			if stringEquals(data, start, end, key) {
+				s.requiredSeen.add(j)
+			}
+		}
+	}
+	if len(s.verifier.URLKeys) > 0 {
+		s.urlKey = ""
+		for _, key := range s.verifier.URLKeys {
+			if stringEquals(data, start, end, key) {
+				s.urlKey = key
+				break
+			}
+		}
+	}
+	if len(s.verifier.stringFormats) > 0 {
+		s.format = formatRule{}
+		for _, rule := range s.verifier.stringFormats {
+			if stringEquals(data, start, end, rule.key) {
+				s.format = rule
+				break
+			}
+		}
+	}
+	if len(s.verifier.epochRanges) > 0 {
+		s.epoch = nil
+		for j, rule := range s.verifier.epochRanges {
+			if stringEquals(data, start, end, rule.key) {
+				s.epoch = &s.verifier.epochRanges[j]
+				break
+			}
+		}
+	}
+	return nil
 }
 
 // HELPERS
@@ -405,7 +3855,7 @@ func isValidTrue(data []byte, i int) (outi int, ok bool) {
 		data[i+2] == 'e' {
 		return i + 3, true
 	}
-	return i, false
+	return literalMismatch(data, i, "rue"), false
 }
 
 func isValidFalse(data []byte, i int) (outi int, ok bool) {
@@ -413,7 +3863,7 @@ func isValidFalse(data []byte, i int) (outi int, ok bool) {
 		data[i+2] == 's' && data[i+3] == 'e' {
 		return i + 4, true
 	}
-	return i, false
+	return literalMismatch(data, i, "alse"), false
 }
 
 func isValidNull(data []byte, i int) (newI int, ok bool) {
@@ -421,7 +3871,28 @@ func isValidNull(data []byte, i int) (newI int, ok bool) {
 		data[i+2] == 'l' {
 		return i + 3, true
 	}
-	return i, false
+	return literalMismatch(data, i, "ull"), false
+}
+
+// literalMismatch returns the offset of the first byte from i
+// not matching the rest of a literal, so the walk stops after the
+// consistent prefix of a truncated literal.
+func literalMismatch(data []byte, i int, rest string) int {
+	for k := 0; k < len(rest) && i < len(data) && data[i] == rest[k]; k++ {
+		i++
+	}
+	return i
+}
+
+// skipNumber returns the offset after the number bytes,
+// without validating the number grammar.
+func skipNumber(data []byte, i int) int {
+	for i < len(data) && (data[i] >= '0' && data[i] <= '9' ||
+		data[i] == '-' || data[i] == '+' || data[i] == '.' ||
+		data[i] == 'e' || data[i] == 'E') {
+		i++
+	}
+	return i
 }
 
 func isValidNumber(data []byte, i int) (newI int, ok bool) {
@@ -436,13 +3907,16 @@ func isValidNumber(data []byte, i int) (newI int, ok bool) {
 	}
 	if data[i] == '0' {
 		i++
-	} else {
+	} else if data[i] >= '1' && data[i] <= '9' {
 		for ; i < len(data); i++ {
 			if data[i] >= '0' && data[i] <= '9' {
 				continue
 			}
 			break
 		}
+	} else {
+		// a minus sign not followed by a digit
+		return i, false
 	}
 	// frac
 	if i == len(data) {
@@ -509,43 +3983,90 @@ func isValidComma(data []byte, i int, end byte) (outi int, ok bool) {
 	return i, false
 }
 
-func isValidColon(data []byte, i int) (outi int, ok bool) {
+// isValidColon checks a colon follows, skipping the whitespace
+// around it, ws is the number of whitespace bytes skipped.
+func isValidColon(data []byte, i int) (outi int, ok bool, ws int) {
+	start := i
 	for ; i < len(data); i++ {
 		switch data[i] {
 		default:
-			return i, false
+			return i, false, 0
 		case ' ', '\t', '\n', '\r':
 			continue
 		case ':':
-			return i + 1, true
+			ws = i - start
+			for i++; i < len(data); i++ {
+				if data[i] != ' ' && data[i] != '\t' &&
+					data[i] != '\n' && data[i] != '\r' {
+					break
+				}
+				ws++
+			}
+			return i, true, ws
 		}
 	}
-	return i, false
+	return i, false, 0
 }
 
-func isValidJSON(data []byte, i int, depth *int, verifier *Verify) (outi int, ok bool, err error) {
+func isValidJSON(data []byte, i int, s *state) (outi int, ok bool, err error) {
 	for ; i < len(data); i++ {
 		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case '{', '[':
+		case '"', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
+			't', 'f', 'n':
+			if s.verifier.RequireStructuredTopLevel {
+				return i, false, ErrBareTopLevelValue
+			}
 		default:
-			i, ok, err = validany(data, i, depth,
-				verifier)
-			if !ok || err != nil {
+			return i, false, firstByteError(data[i], i)
+		}
+		start, entries := i, s.entries
+		i, ok, err = validany(data, i, s)
+		if !ok || err != nil {
+			return i, false, err
+		}
+		if len(s.verifier.RequiredTopLevelKeys) > 0 {
+			if err = s.checkRequiredKeys(data[start]); err != nil {
 				return i, false, err
 			}
-			for ; i < len(data); i++ {
-				switch data[i] {
-				default:
-					return i, false, err
-				case ' ', '\t', '\n', '\r':
-					continue
-				}
+		}
+		for ; i < len(data); i++ {
+			switch data[i] {
+			default:
+				return i, false, err
+			case ' ', '\t', '\n', '\r':
+				continue
+			}
+		}
+		if s.verifier.objectFillEnabled && s.totalObjects > 0 {
+			// the entries of a stream add up across its documents
+			avg := float64(s.entries-entries) / float64(s.totalObjects)
+			if avg < s.verifier.MinAverageObjectFill {
+				return i, false, fmt.Errorf("jtp.objectsTooSparse.Min-[%g]-"+
+					"Allowed.Found-[%g]", s.verifier.MinAverageObjectFill, avg)
+			}
+		}
+		if s.verifier.valueFractionEnabled {
+			if err = s.checkValueFraction(); err != nil {
+				return i, false, err
 			}
-			return i, true, err
-		case ' ', '\t', '\n', '\r':
-			continue
 		}
+		if s.verifier.tokenGapEnabled {
+			if err = s.checkTokenGap(i); err != nil {
+				return i, false, err
+			}
+		}
+		if s.verifier.scanBudgetEnabled {
+			if err = s.checkScanBudget(i); err != nil {
+				return i, false, err
+			}
+		}
+		return i, true, err
 	}
-	return i, false, err
+	// no value found, input is empty or only whitespace
+	return i, false, ErrEmptyInput
 }
 
 // VerifyBytes returns true if the input is valid json,
@@ -553,14 +4074,251 @@ func isValidJSON(data []byte, i int, depth *int, verifier *Verify) (outi int, ok
 // A successful VerifyBytes returns err == nil,
 // Callers should treat a return of true and nil as only success case.
 func (v Verify) VerifyBytes(json []byte) (bool, error) {
-	var depth int
-	_, ok, err := isValidJSON(json, 0, &depth, &v)
+	if v.allowlisted(json) {
+		return true, nil
+	}
+	// fast path, no walk for {}, 42 and the like
+	if isTrivialJSON(json) && v.trivialChecksOnly() {
+		return true, nil
+	}
+	s := state{verifier: v}
+	return s.verify(json)
+}
+
+// allowlisted reports if json is one of the documents passed to
+// WithAllowlist.
+func (v Verify) allowlisted(json []byte) bool {
+	if v.allowlist == nil {
+		return false
+	}
+	_, allowed := v.allowlist[string(json)]
+	return allowed
+}
+
+// trivialJSONLength is the maximum length of a trivial JSON.
+const trivialJSONLength = 16
+
+// isTrivialJSON reports if json is exactly one of the complete
+// documents {}, [], null, true, false or a short integer,
+// without any whitespace.
+func isTrivialJSON(json []byte) bool {
+	if len(json) == 0 || len(json) > trivialJSONLength {
+		return false
+	}
+	switch string(json) {
+	case "{}", "[]", "null", "true", "false":
+		return true
+	}
+	digits := json
+	if digits[0] == '-' {
+		digits = digits[1:]
+	}
+	if len(digits) == 0 || (digits[0] == '0' && len(digits) > 1) {
+		return false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// firstByteError returns the error of the byte c at offset i which
+// can't begin any JSON value. A JSON structural or number byte, like
+// the } of a truncated document, is a malformed JSON, any other byte,
+// like the < of XML or HTML, is not JSON at all.
+func firstByteError(c byte, i int) error {
+	switch c {
+	case '}', ']', ':', ',', '+', '.':
+		return &MalformedError{Reason: unexpectedByte, Offset: i}
+	}
+	return fmt.Errorf("jtp.notJSON.FirstByte-[%c]", c)
+}
+
+// quickReject checks the last non whitespace byte of the json closes
+// the value opened by the first one, see WithQuickReject.
+func quickReject(json []byte) error {
+	first := skipSpace(json, 0)
+	if first == len(json) {
+		return ErrEmptyInput
+	}
+	last := len(json) - 1
+	for json[last] == ' ' || json[last] == '\t' || json[last] == '\n' ||
+		json[last] == '\r' {
+		last--
+	}
+	var ok bool
+	switch c, end := json[first], json[last]; c {
+	case '{':
+		ok = end == '}'
+	case '[':
+		ok = end == ']'
+	case '"':
+		ok = end == '"' && last > first
+	case 't', 'f':
+		ok = end == 'e'
+	case 'n':
+		ok = end == 'l'
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		ok = end >= '0' && end <= '9'
+	default:
+		return firstByteError(c, first)
+	}
+	if !ok {
+		return fmt.Errorf("jtp.quickReject.FirstByte-[%c].LastByte-[%c]",
+			json[first], json[last])
+	}
+	return nil
+}
+
+// trivialChecksOnly reports if v passes every trivial JSON, i.e. none
+// of its checks can reject one. An option able to reject a trivial
+// JSON, like its size or top level type, must be listed here.
+func (v *Verify) trivialChecksOnly() bool {
+	return !v.documentSizeEnabled && !v.cumulativeBytesEnabled &&
+		!v.RequireStructuredTopLevel && !v.TopLevelMustBeNonEmpty &&
+		!v.arrayLiteralBytesEnabled && !v.tokenGapEnabled &&
+		!v.tokenBytesEnabled && !v.objectFillEnabled &&
+		!v.canonicalSizeEnabled &&
+		!v.scanBudgetEnabled && v.typeSchema == nil &&
+		len(v.discriminators) == 0 &&
+		len(v.RequiredTopLevelKeys) == 0 && !v.DisallowNull &&
+		v.versionedLimits == nil &&
+		!v.totalNumberBytesEnabled &&
+		v.approachFn == nil && !v.numberContentEnabled() &&
+		// zero only in the envelope of a scalar
+		!(v.jsonContainerDepthEnabled && v.JSONContainerDepth == 0)
+}
+
+// VerifyBytesContext is like VerifyBytes but stops the validation
+// and returns ctx.Err() once the ctx is done.
+// The ctx is checked periodically between the values of the JSON.
+func (v Verify) VerifyBytesContext(ctx context.Context,
+	json []byte) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s := state{verifier: v, ctx: ctx}
+	return s.verify(json)
+}
+
+func (s *state) verify(json []byte) (bool, error) {
+	if s.verifier.documentSizeEnabled && len(json) > s.verifier.MaxDocumentSize {
+		return false, newLimitError(documentSize,
+			s.verifier.MaxDocumentSize, len(json), s.verifier.MaxDocumentSize)
+	}
+	if s.verifier.approachFn != nil && s.verifier.documentSizeEnabled {
+		s.approach(documentSize, 0, len(json), s.verifier.MaxDocumentSize)
+	}
+	if n := s.streamBytes + len(json); s.verifier.cumulativeBytesEnabled &&
+		n > s.verifier.CumulativeMaxBytes {
+		return false, newLimitError(cumulativeBytes,
+			s.verifier.CumulativeMaxBytes, n,
+			s.verifier.CumulativeMaxBytes-s.streamBytes)
+	}
+	if s.verifier.QuickReject {
+		if err := quickReject(json); err != nil {
+			return false, err
+		}
+	}
+	if s.verifier.versionedLimits != nil {
+		if err := s.verifier.applyVersionLimits(json); err != nil {
+			return false, err
+		}
+	}
+	if s.verifier.ASCIIOnly {
+		s.rescanned += len(json)
+		for i, c := range json {
+			if c >= utf8.RuneSelf {
+				return false, fmt.Errorf("jtp.nonASCIIByte.Offset-[%d]."+
+					"Byte-[%#x]", i, c)
+			}
+		}
+	}
+	if s.verifier.typeSchema != nil {
+		s.h = &schemaHandler{schema: s.verifier.typeSchema,
+			pathForwarder: pathForwarder{forwarder: forwarder{s.h}}}
+	}
+	if len(s.verifier.discriminators) > 0 {
+		s.h = &discriminatorHandler{rules: s.verifier.discriminators,
+			pathForwarder: pathForwarder{forwarder: forwarder{s.h}}}
+	}
+	if s.verifier.RejectCoercibleKeyCollisions {
+		s.h = &coercionHandler{forwarder: forwarder{s.h}}
+	}
+	if s.verifier.canonicalSizeEnabled {
+		s.h = &canonicalSizeHandler{max: s.verifier.MaxCanonicalSize,
+			forwarder: forwarder{s.h}}
+	}
+	if s.verifier.UniformObjectKeysInArrays {
+		s.h = &keySetHandler{
+			pathForwarder: pathForwarder{forwarder: forwarder{s.h}}}
+	}
+	if len(s.verifier.uniformValues) > 0 {
+		s.h = &uniformHandler{rules: s.verifier.uniformValues,
+			pending: -1, forwarder: forwarder{s.h}}
+	}
+	if s.verifier.RejectBidiControls {
+		s.h = &bidiHandler{
+			pathForwarder: pathForwarder{forwarder: forwarder{s.h}}}
+	}
+	if s.verifier.charRunEnabled {
+		s.h = &charRunHandler{max: s.verifier.MaxCharRunInString,
+			pathForwarder: pathForwarder{forwarder: forwarder{s.h}}}
+	}
+	if s.verifier.entropyEnabled {
+		s.h = &entropyHandler{
+			pathForwarder: pathForwarder{forwarder: forwarder{s.h}},
+			max:           s.verifier.MaxStringValueEntropy,
+			minLen:        s.verifier.MinEntropyStringLength}
+	}
+	if s.verifier.FlagEmbeddedJSON {
+		s.h = &embeddedFlagHandler{
+			pathForwarder: pathForwarder{forwarder: forwarder{s.h}}}
+	}
+	if s.verifier.keyValueRatioEnabled {
+		s.h = &ratioHandler{max: s.verifier.MaxKeyValueLengthRatio,
+			forwarder: forwarder{s.h}}
+	}
+	if len(s.verifier.sortedArrays) > 0 {
+		s.h = &sortedHandler{rules: s.verifier.sortedArrays,
+			pending: -1, forwarder: forwarder{s.h}}
+	}
+	var ok bool
+	var err error
+	s.end, ok, err = isValidJSON(json, 0, s)
 	if err == nil && ok == false {
 		err = ErrInvalidJSON
 	}
+	if le, isLimit := err.(*LimitError); isLimit {
+		switch le.Kind {
+		case containerDepth:
+			le.Path = containerPointer(json, le.Offset)
+		case objectEntryCount, objectKeyValueLength, topLevelKeyLength,
+			keyByteLength, keyUTF16Units:
+			le.Path = objectPointer(json, le.Offset)
+		case stringValueLength, stringValueWeight, stringUTF16Units:
+			le.Path = valuePointer(json, le.Offset)
+		}
+	}
 	return ok, err
 }
 
+// IsValid returns true if the input is valid json (RFC 8259),
+// without any JSON THREAT Protection limit.
+func IsValid(json []byte) bool {
+	s := state{verifier: Verify{}}
+	_, ok, err := isValidJSON(json, 0, &s)
+	return ok && err == nil
+}
+
+// IsValidString returns true if the input is valid json (RFC 8259),
+// without any JSON THREAT Protection limit.
+func IsValidString(json string) bool {
+	return IsValid([]byte(json))
+}
+
 // VerifyString returns true if the input is valid json,
 // and is JSON THREAT Protection Safe.
 // A successful VerifyString returns err == nil,
@@ -1,8 +1,13 @@
 package gojtp
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func ExampleNew() {
@@ -97,7 +102,7 @@ func TestIsValidateString1(t *testing.T) {
 	}
 	for _, tc := range scenarios {
 		t.Run(tc.str, func(t *testing.T) {
-			_, ok := isValidateString([]byte(tc.str), 0)
+			_, ok := isValidateString([]byte(tc.str), 0, false)
 			if ok != tc.isString {
 				t.Errorf("Expected %v Got %v", tc.isString, ok)
 			}
@@ -105,40 +110,2432 @@ func TestIsValidateString1(t *testing.T) {
 	}
 }
 
+func TestIsValidateKey(t *testing.T) {
+	t.Parallel()
+	scenarios := []string{
+		`"plain"`,
+		`"i ♥ u"`,
+		`"Example \u2764\ufe0f"`,
+		`"\ud83d\ude00 pair"`,
+		`"\ud83d\ud83d\ude00 high then pair"`,
+		`"\ude00\ud83d lone"`,
+		`"\n\t\"\\\/"`,
+		"\"\xff\xfe invalid utf-8\"",
+		`"\u12"`,
+		`"\x"`,
+		`"unterminated`,
+		"\"raw\ncontrol\"",
+	}
+	for _, str := range scenarios {
+		t.Run(str, func(t *testing.T) {
+			data := []byte(str)
+			expI, expOk := isValidateString(data, 1, false)
+			i, runes, ok := isValidateKey(data, 1)
+			if i != expI || ok != expOk {
+				t.Fatalf("Expected %d %v Got %d %v", expI, expOk, i, ok)
+			}
+			if ok && runes != decodedRuneCount(data, 0, i) {
+				t.Errorf("Expected %d runes Got %d",
+					decodedRuneCount(data, 0, i), runes)
+			}
+		})
+	}
+}
+
 func TestValidStringLengthUTF8(t *testing.T) {
 	t.Parallel()
-	maxAllowed := 10
+	maxAllowed := 10
+	scenarios := []struct {
+		str []byte
+		err error
+	}{
+		{
+			str: []byte("Hello, 世界"),
+			err: nil,
+		},
+		{
+			str: []byte(`i ♥ u`),
+			err: nil,
+		},
+		{
+			str: []byte(`"Hello, World!"`),
+			err: fmt.Errorf("jtp.maxStringValueLengthReached.Max-[10]-Allowed.Found-[13]"),
+		},
+	}
+
+	for _, tc := range scenarios {
+		t.Run(string(tc.str), func(t *testing.T) {
+			e := validateStringLength(tc.str, 0, len(tc.str),
+				true, maxAllowed, stringValueLength, decodedRuneCount)
+			if tc.err == nil && e != nil {
+				t.Errorf("Expected an nil error Got - %v", e)
+			}
+			if tc.err != nil && e == nil {
+				t.Errorf("Expected an not nil error Got - nil")
+			}
+			if tc.err != nil && e != nil && e.Error() != tc.err.Error() {
+				t.Errorf("Expected error to be %s Got %s", tc.err.Error(), e.Error())
+			}
+		})
+	}
+}
+
+func TestMaxStringWeight(t *testing.T) {
+	t.Parallel()
+	verifier, err := New(WithMaxStringWeight(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "ascii 6 runes",
+			json: `{"a": "abcdef"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "cjk 6 runes",
+			json: `{"a": "世界世界世界"}`,
+			err:  fmt.Errorf("jtp.maxStringWeightReached.Max-[10]-Allowed.Found-[18]"),
+			ok:   false,
+		},
+		{
+			name: "escaped cjk 4 runes",
+			json: `{"a": "\u4e2d\u4e2d\u4e2d\u4e2d"}`,
+			err:  fmt.Errorf("jtp.maxStringWeightReached.Max-[10]-Allowed.Found-[12]"),
+			ok:   false,
+		},
+		{
+			name: "escaped cjk 3 runes",
+			json: `{"a": "\u4e2d\u4e2d\u4e2d"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "escaped control and backslash",
+			json: `{"a": "\n\n\n\n\n\n", "b": "\\\\\\\\\\\\\\\\"}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRejectReplacementChar(t *testing.T) {
+	t.Parallel()
+	verifier, err := New(WithRejectReplacementChar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "escaped replacement char",
+			json: `{"a": "ab\uFFFD"}`,
+			err:  fmt.Errorf("jtp.replacementCharacterPresent.Offset-[9]"),
+			ok:   false,
+		},
+		{
+			name: "raw replacement char",
+			json: "{\"a\": \"ab\xef\xbf\xbd\"}",
+			err:  fmt.Errorf("jtp.replacementCharacterPresent.Offset-[9]"),
+			ok:   false,
+		},
+		{
+			name: "other escapes",
+			json: `{"a": "Hello, \u4e16\u754c \uD83D\uDE00"}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxTotalEscapes(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxTotalEscapes(8), WithMaxConsecutiveEscapes(2))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "within",
+			json: `{"a\n": ["x\ty", "\u00e9", "\\"], "b": "\"q\""}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "many modestly escaped strings",
+			json: `["a\nb", "c\nd", "e\nf", "g\nh", {"i\nj": "k\nl"}, "m\nn", "o\np", "q\nr"]`,
+			err:  fmt.Errorf("jtp.maxTotalEscapesReached.Max-[8]-Allowed.Found-[9]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxConsecutiveEscapes(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxConsecutiveEscapes(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "long backslash run",
+			json: `{"a": "\\\\\\\\"}`,
+			err:  fmt.Errorf("jtp.maxConsecutiveEscapesReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "long backslash run in key",
+			json: `{"\\\\\\\\": "a"}`,
+			err:  fmt.Errorf("jtp.maxConsecutiveEscapesReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "same escapes spread out",
+			json: `{"a": "\\a\\b\\c\\"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "unicode escapes run",
+			json: `{"a": "\u0041\u0042\n\t"}`,
+			err:  fmt.Errorf("jtp.maxConsecutiveEscapesReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestValidStringLengthDecoded(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(3), WithMaxObjectKeyLength(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "escaped value",
+			json: `{"a": "\u0041\u0042\u0043\n"}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "escaped key",
+			json: `{"\u0041\t\"\/": "a"}`,
+			err:  fmt.Errorf("jtp.maxKeyLengthReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "escaped within limit",
+			json: `{"\u4e16\u754c": "\u0041\\\""}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "surrogate pair key",
+			json: `{"\uD83D\uDE00\ud83d\ude01x": "a"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "surrogate pair key past limit",
+			json: `{"\uD83D\uDE00\uD83D\uDE00\uD83D\uDE00\uD83D\uDE00": "a"}`,
+			err:  fmt.Errorf("jtp.maxKeyLengthReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "unpaired surrogates",
+			json: `{"\uD83D\u0041\uDE00\uD83D": "a"}`,
+			err:  fmt.Errorf("jtp.maxKeyLengthReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxDecodedMemoryEstimate(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxDecodedMemoryEstimate(100))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "one long string",
+			json: `["aaaaaaaaaaaaaaaaaaa"]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "many elements",
+			json: `[1,1,1,1,1,1,1,1,1,1,1]`,
+			err:  fmt.Errorf("jtp.maxDecodedMemoryEstimateReached.Max-[100]-Allowed.Found-[112]"),
+			ok:   false,
+		},
+		{
+			name: "object entries",
+			json: `{"a":1,"b":2,"c":3}`,
+			err:  fmt.Errorf("jtp.maxDecodedMemoryEstimateReached.Max-[100]-Allowed.Found-[147]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			if len(scenarios[0].json) != len(scenarios[1].json) {
+				t.Fatal("Expected documents of equal byte size")
+			}
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	t.Run("tuned costs", func(t *testing.T) {
+		verifier, _ := New(WithMaxDecodedMemoryEstimate(100),
+			WithDecodedMemoryCosts(MemoryCosts{ObjectEntry: 1,
+				ArrayElement: 1}))
+		ok, err := verifier.VerifyString(scenarios[1].json)
+		checkResult(t, true, ok, nil, err)
+	})
+}
+
+func TestMaxContainerCount(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerCount(4))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "many sibling containers",
+			json: `[{}, [], {}, [], {}]`,
+			err:  fmt.Errorf("jtp.maxContainerCountReached.Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "within limit",
+			json: `{"a": [{}, []]}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestStringValueCharset(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithStringValueCharset(func(r rune) bool {
+		return r < utf8.RuneSelf
+	}))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "cjk value",
+			json: `{"a": "Hello, 世界"}`,
+			err:  fmt.Errorf("jtp.disallowedStringCharacter.Char-[世].Offset-[14]"),
+			ok:   false,
+		},
+		{
+			name: "escaped cjk value",
+			json: `{"a": "\u4e16"}`,
+			err:  fmt.Errorf("jtp.disallowedStringCharacter.Char-[世].Offset-[7]"),
+			ok:   false,
+		},
+		{
+			name: "ascii value and cjk key",
+			json: `{"世界": "Hello, World\n"}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestStringValidator(t *testing.T) {
+	t.Parallel()
+	errSQL := errors.New("sql statement")
+	verifier, _ := New(WithStringValidator(func(value []byte, isKey bool) error {
+		if bytes.Contains(bytes.ToUpper(value), []byte("DROP TABLE")) {
+			return errSQL
+		}
+		return nil
+	}))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{name: "clean", json: `{"q": "drop the table"}`, err: nil, ok: true},
+		{
+			name: "value",
+			json: `{"q": "1; drop table users"}`,
+			err:  fmt.Errorf("jtp.stringRejected.Offset-[6].Reason-[sql statement]"),
+			ok:   false,
+		},
+		{
+			name: "key",
+			json: `[{"DROP TABLE": 1}]`,
+			err:  fmt.Errorf("jtp.keyRejected.Offset-[2].Reason-[sql statement]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			var re *RejectedError
+			if tc.err != nil && (!errors.As(err, &re) || re.Err != errSQL) {
+				t.Errorf("Expected a *RejectedError wrapping the validator error")
+			}
+		})
+	}
+}
+
+func TestMaxDocumentSize(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxDocumentSize(8))
+	ok, err := verifier.VerifyString(`{"a": 1}`)
+	checkResult(t, true, ok, nil, err)
+	ok, err = verifier.VerifyString(`{"a": 10}`)
+	checkResult(t, false, ok,
+		fmt.Errorf("jtp.maxDocumentSizeReached.Max-[8]-Allowed.Found-[9]"), err)
+}
+
+func TestMaxBytesPerChild(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxBytesPerChild(20))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "lean containers",
+			json: `{"a": [1, 2, 3], "b": "hello", "c": {}, "d": []}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "fat object",
+			json: `{"a": 1, "b": "` + strings.Repeat("x", 1000) + `"}`,
+			err:  fmt.Errorf("jtp.maxBytesPerChildReached.Container-[object].Max-[20]-Allowed.Found-[508]"),
+			ok:   false,
+		},
+		{
+			name: "fat array",
+			json: `[[1,     ` + strings.Repeat(" ", 40) + `2]]`,
+			err:  fmt.Errorf("jtp.maxBytesPerChildReached.Container-[array].Max-[20]-Allowed.Found-[25]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxConsecutiveOpens(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxConsecutiveOpens(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "values in between",
+			json: `[[1], [[2]], {"a": [[3]]}, [[], [[]]]]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "keys don't count",
+			json: `[[{"a": [1]}]]`,
+			err:  fmt.Errorf("jtp.maxConsecutiveOpensReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "bomb",
+			json: strings.Repeat("[", 1<<20),
+			err:  fmt.Errorf("jtp.maxConsecutiveOpensReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxStructureTransitions(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStructureTransitions(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "same type nesting",
+			json: `[[[[1]]], {"a": {"b": {"c": {}}}}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "records",
+			json: `[{"a": 1}, {"b": 2}, {"c": 3}, {"d": 4}]`,
+			err:  fmt.Errorf("jtp.maxStructureTransitionsReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "alternating",
+			json: `{"a": [{"b": [{"c": 1}]}]}`,
+			err:  fmt.Errorf("jtp.maxStructureTransitionsReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{name: "at the limit", json: `{"a": [{"b": [1]}]}`, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxStructuralStates(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStructuralStates(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "uniform",
+			json: `[[1], [2], [3], [4], [5], [6]]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "varied",
+			json: `{"a": [{"b": [{}]}]}`,
+			err:  fmt.Errorf("jtp.maxStructuralStatesReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxUTF16Units(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringUTF16Units(1), WithMaxKeyUTF16Units(1))
+	runes, _ := New(WithMaxStringLength(1), WithMaxObjectKeyLength(1))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "bmp value",
+			json: `{"a": "世"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "astral value",
+			json: `{"a": "😀"}`,
+			err:  fmt.Errorf("jtp.maxStringUTF16UnitsReached.Max-[1]-Allowed.Found-[2]"),
+			ok:   false,
+		},
+		{
+			name: "escaped astral value",
+			json: `{"a": "\uD83D\uDE00"}`,
+			err:  fmt.Errorf("jtp.maxStringUTF16UnitsReached.Max-[1]-Allowed.Found-[2]"),
+			ok:   false,
+		},
+		{
+			name: "astral key",
+			json: `{"😀": "a"}`,
+			err:  fmt.Errorf("jtp.maxKeyUTF16UnitsReached.Max-[1]-Allowed.Found-[2]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			// a single rune passes the rune count limits
+			ok, err = runes.VerifyString(tc.json)
+			checkResult(t, true, ok, nil, err)
+		})
+	}
+}
+
+func TestRejectNullCharInStrings(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectNullCharInStrings())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `["a\u0041\n\u0100", 0]`, err: nil, ok: true},
+		{
+			json: `["\u0000"]`,
+			err:  fmt.Errorf("jtp.nullCharacterInString.Offset-[2]"),
+			ok:   false,
+		},
+		{
+			json: `{"ab\u0000": 1}`,
+			err:  fmt.Errorf("jtp.nullCharacterInString.Offset-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRejectNumericKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectNumericKeys())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `{"id0": 1, "": {"0x": "0"}}`, err: nil, ok: true},
+		{
+			json: `{"0": 1}`,
+			err:  fmt.Errorf("jtp.numericObjectKey.Key-[0]"),
+			ok:   false,
+		},
+		{
+			json: `[{"a": {"12\u0033": 1}}]`,
+			err:  fmt.Errorf("jtp.numericObjectKey.Key-[123]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestForwardSlashPolicy(t *testing.T) {
+	t.Parallel()
+	scenarios := []struct {
+		name   string
+		policy SlashPolicy
+		json   string
+		err    error
+		ok     bool
+	}{
+		{name: "allow raw", policy: SlashAllow, json: `"a/b"`, ok: true},
+		{name: "allow escaped", policy: SlashAllow, json: `"a\/b"`, ok: true},
+		{
+			name:   "require escaped raw",
+			policy: SlashRequireEscaped,
+			json:   `"a/b"`,
+			err:    fmt.Errorf("jtp.forwardSlashPolicyViolation.Offset-[2]"),
+			ok:     false,
+		},
+		{
+			name:   "require escaped escaped",
+			policy: SlashRequireEscaped,
+			json:   `"a\/b"`,
+			ok:     true,
+		},
+		{
+			name:   "require escaped key",
+			policy: SlashRequireEscaped,
+			json:   `{"a/b": 1}`,
+			err:    fmt.Errorf("jtp.forwardSlashPolicyViolation.Offset-[3]"),
+			ok:     false,
+		},
+		{name: "forbid escaped raw", policy: SlashForbidEscaped, json: `"a/b"`, ok: true},
+		{
+			name:   "forbid escaped escaped",
+			policy: SlashForbidEscaped,
+			json:   `"a\/b"`,
+			err:    fmt.Errorf("jtp.forwardSlashPolicyViolation.Offset-[2]"),
+			ok:     false,
+		},
+	}
+	for _, tc := range scenarios {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			verifier, err := New(WithForwardSlashPolicy(tc.policy))
+			if err != nil {
+				t.Fatalf("Expected an nil error Got - %v", err)
+			}
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithForwardSlashPolicy(3)); err == nil {
+		t.Errorf("Expected an error for an unknown policy")
+	}
+}
+
+func TestRejectNoncharacters(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectNoncharacters())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `["normal \uFFFD \uFDCF \uFFFC", "\ud83d\ude00"]`, err: nil, ok: true},
+		{
+			json: `["a\uFFFE"]`,
+			err:  fmt.Errorf("jtp.unicodeNoncharacter.CodePoint-[U+FFFE]"),
+			ok:   false,
+		},
+		{
+			json: "[\"a\xef\xbf\xbe\"]",
+			err:  fmt.Errorf("jtp.unicodeNoncharacter.CodePoint-[U+FFFE]"),
+			ok:   false,
+		},
+		{
+			json: `{"\ufdd0": 1}`,
+			err:  fmt.Errorf("jtp.unicodeNoncharacter.CodePoint-[U+FDD0]"),
+			ok:   false,
+		},
+		{
+			json: `["\ud83f\udfff"]`,
+			err:  fmt.Errorf("jtp.unicodeNoncharacter.CodePoint-[U+1FFFF]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxScriptsPerString(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxScriptsPerString(1))
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `["paypal.com", "Ελλάδα 2024!", "café", "東京"]`, err: nil, ok: true},
+		{json: `{"pаypal": 1}`, err: nil, ok: true},
+		{
+			// Cyrillic а (U+0430) among Latin letters
+			json: `["pаypal.com"]`,
+			err:  fmt.Errorf("jtp.tooManyScriptsInString.Max-[1]-Allowed.Found-[2]"),
+			ok:   false,
+		},
+		{
+			json: `["p\u0430ypal"]`,
+			err:  fmt.Errorf("jtp.tooManyScriptsInString.Max-[1]-Allowed.Found-[2]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxCombiningMarks(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxCombiningMarks(2))
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `["café", "cafe\u0301", "Tiê\u0301ng Vie\u0302\u0323t"]`, err: nil, ok: true},
+		{
+			json: `["Z\u0351\u0362\u0324algo"]`,
+			err:  fmt.Errorf("jtp.tooManyCombiningMarks.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			json: "[\"He̵̡̢llo\"]",
+			err:  fmt.Errorf("jtp.tooManyCombiningMarks.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRejectZeroWidthChars(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectZeroWidthChars())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `{"a\u200b": ["normal text", "\u2060"]}`, err: nil, ok: true},
+		{
+			json: "[\"admin\u200b\"]",
+			err:  fmt.Errorf("jtp.zeroWidthCharacter.CodePoint-[U+200B].Offset-[7]"),
+			ok:   false,
+		},
+		{
+			json: `["ad\uFEFFmin"]`,
+			err:  fmt.Errorf("jtp.zeroWidthCharacter.CodePoint-[U+FEFF].Offset-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRejectExtendedControls(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectExtendedControls())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `["~\u00a0é", "\u007e"]`, err: nil, ok: true},
+		{
+			json: "[\"a\x7fb\"]",
+			err:  fmt.Errorf("jtp.extendedControlCharacter.CodePoint-[U+007F]"),
+			ok:   false,
+		},
+		{
+			json: `["\u007F"]`,
+			err:  fmt.Errorf("jtp.extendedControlCharacter.CodePoint-[U+007F]"),
+			ok:   false,
+		},
+		{
+			json: "{\"a\u009bb\": 1}",
+			err:  fmt.Errorf("jtp.extendedControlCharacter.CodePoint-[U+009B]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestKeysMustBeIdentifiers(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithKeysMustBeIdentifiers())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `{"validKey": {"_x1": "1bad value"}}`, err: nil, ok: true},
+		{json: `{"valid\u004bey": 1}`, err: nil, ok: true},
+		{
+			json: `{"1bad": 1}`,
+			err:  fmt.Errorf("jtp.keyNotIdentifier.Key-[1bad]"),
+			ok:   false,
+		},
+		{
+			json: `[{"ok": 1, "has space": 2}]`,
+			err:  fmt.Errorf("jtp.keyNotIdentifier.Key-[has space]"),
+			ok:   false,
+		},
+		{
+			json: `{"": 1}`,
+			err:  fmt.Errorf("jtp.keyNotIdentifier.Key-[]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxSubtreeEntries(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxSubtreeEntries(5), WithMaxObjectEntryCount(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "small subtree",
+			json: `{"a": {"b": 1, "c": [{"d": 1}]}, "e": 2}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "separate subtrees in an array",
+			json: `[{"a": 1, "b": {"c": 1, "d": 1}}, {"a": 1, "b": {"c": 1, "d": 1}}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "huge nested subtree",
+			json: `{"a": [{"b": 1, "c": 2, "d": 3}, {"e": {"f": 1, "g": 2}}]}`,
+			err:  fmt.Errorf("jtp.maxSubtreeEntriesReached.Max-[5]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxIdenticalValueRepeats(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxIdenticalValueRepeats(3),
+		WithTrackedValueLength(8))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "distinct values",
+			json: `[1, 1, 1, 2, 2, "a", "a", "a", true, true, true]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "repeated number",
+			json: `[1, 2, 1, 3, 1, 1]`,
+			err:  fmt.Errorf("jtp.maxIdenticalValueRepeatsReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "repeated string across objects",
+			json: `[{"a": "x"}, {"b": "x"}, {"c": ["x", "x"]}]`,
+			err:  fmt.Errorf("jtp.maxIdenticalValueRepeatsReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "long values not tracked",
+			json: `["abcdefghij", "abcdefghij", "abcdefghij", "abcdefghij"]`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxValueRepetitionFraction(t *testing.T) {
+	t.Parallel()
+	// 16 values, 80% of them "pad"
+	padded := `[` + strings.Repeat(`"pad", `, 13) + `1, 2, "x"]`
+	scenarios := []struct {
+		name string
+		json string
+		frac float64
+		err  error
+		ok   bool
+	}{
+		{
+			name: "padded",
+			json: padded,
+			frac: 0.5,
+			err:  fmt.Errorf(`jtp.valueRepetitionFractionExceeded.Value-["pad"]`),
+			ok:   false,
+		},
+		{name: "padded under the fraction", json: padded, frac: 0.9, err: nil, ok: true},
+		{
+			name: "varied values",
+			json: `[` + strings.Repeat(`1, 2, 3, 4, `, 5) + `5]`,
+			frac: 0.3,
+			err:  nil,
+			ok:   true,
+		},
+		{name: "few values", json: `{"a": true, "b": true}`, frac: 0.5, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier, _ := New(WithMaxValueRepetitionFraction(tc.frac))
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithMaxValueRepetitionFraction(1)); err == nil {
+		t.Errorf("Expected an error for a fraction of 1")
+	}
+}
+
+func TestMaxGlobalKeyRepeats(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxGlobalKeyRepeats(3), WithTrackedKeyLength(10))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "within limit",
+			json: `[{"header_key": 1, "a": 1}, {"header_key": 2}, {"header_key": {"a": 2}}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "repeated across objects",
+			json: `[{"header_key": 1}, {"header_key": 2}, {"x": {"header_key": 3}}, {"header_key": 4}]`,
+			err:  fmt.Errorf("jtp.maxGlobalKeyRepeatsReached.Key-[header_key]"),
+			ok:   false,
+		},
+		{
+			name: "long keys not tracked",
+			json: `[{"header_keys": 1}, {"header_keys": 2}, {"header_keys": 3}, {"header_keys": 4}]`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxColonSurroundingWhitespace(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxColonSurroundingWhitespace(4))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "usual whitespace",
+			json: "{\"a\": 1, \"b\" : {\"c\":\n\t\t2}}",
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "before the colon",
+			json: `{"a"            : 1}`,
+			err:  fmt.Errorf("jtp.maxColonWhitespaceReached.Max-[4]-Allowed.Found-[13]"),
+			ok:   false,
+		},
+		{
+			name: "around the colon",
+			json: `[{"a"   :   1}]`,
+			err:  fmt.Errorf("jtp.maxColonWhitespaceReached.Max-[4]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMinAverageObjectFill(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMinAverageObjectFill(2.5))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "fat objects",
+			json: `[{"a": 1, "b": 2, "c": 3}, {"a": 1, "b": {"c": 1, "d": 2, "e": 3}}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "single entry objects",
+			json: `[{"a": 1}, {"b": 2}, {"c": 3}, {"d": 4}]`,
+			err:  fmt.Errorf("jtp.objectsTooSparse.Min-[2.5]-Allowed.Found-[1]"),
+			ok:   false,
+		},
+		{
+			name: "empty object",
+			json: `{"a": 1, "b": 2, "c": {}}`,
+			err:  fmt.Errorf("jtp.objectsTooSparse.Min-[2.5]-Allowed.Found-[1.5]"),
+			ok:   false,
+		},
+		{
+			name: "trivial object",
+			json: `{}`,
+			err:  fmt.Errorf("jtp.objectsTooSparse.Min-[2.5]-Allowed.Found-[0]"),
+			ok:   false,
+		},
+		{name: "no objects", json: `[1, [2]]`, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithMinAverageObjectFill(-1)); err == nil {
+		t.Errorf("Expected an error for a negative average")
+	}
+}
+
+func TestMaxTotalObjects(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxTotalObjects(100), WithMaxArrayElementCount(200))
+	objects := func(n int) string {
+		return "[" + strings.TrimSuffix(strings.Repeat(`{"a":[1]},`, n), ",") + "]"
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{name: "at the limit", json: objects(100), err: nil, ok: true},
+		{
+			name: "many sibling objects",
+			json: objects(150),
+			err:  fmt.Errorf("jtp.maxTotalObjectsReached.Max-[100]-Allowed.Found-[101]"),
+			ok:   false,
+		},
+		{
+			name: "nested objects",
+			json: `{"a": ` + objects(99) + `}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "nested objects over",
+			json: `{"a": ` + objects(100) + `}`,
+			err:  fmt.Errorf("jtp.maxTotalObjectsReached.Max-[100]-Allowed.Found-[101]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestKeyByteLengthWithValueRuneLength(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxObjectKeyByteLength(4), WithMaxStringLength(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "bytes keys and runes values",
+			json: `{"éé": "ééé", "ab\u00e9": "\u00e9\u00e9\u00e9"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "key over in bytes",
+			json: `{"ééa": "a"}`,
+			err:  fmt.Errorf("jtp.maxKeyByteLengthReached.Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "escaped key over in bytes",
+			json: `{"\u00e9\u00e9\u00e9": "a"}`,
+			err:  fmt.Errorf("jtp.maxKeyByteLengthReached.Max-[4]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+		{
+			name: "value over in runes",
+			json: `{"a": "éééé"}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxTotalKeyBytes(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxTotalKeyBytes(12), WithMaxObjectKeyLength(4))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "sum within limit",
+			json: `{"abcd": 1, "efgh": {"ijkl": 2}}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "sum over limit",
+			json: `[{"abcd": 1, "efgh": 2}, {"ijkl": 3, "m": 4}]`,
+			err:  fmt.Errorf("jtp.maxTotalKeyBytesReached.Max-[12]-Allowed.Found-[13]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestArrayUnitBudget(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithArrayUnitBudget(25, 10))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "within budget",
+			json: `[1, "a", {"a": [1, 2, 3]}, null, {}, [true]]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "objects over budget",
+			json: `[1, {}, 2, {"a": 1},  {"b": 2}]`,
+			err:  fmt.Errorf("jtp.arrayUnitBudgetExceeded.Max-[25]-Allowed.Found-[32]"),
+			ok:   false,
+		},
+		{
+			name: "per array budget",
+			json: `[{}, {}, [{}, {}], [{}, {}]]`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithArrayUnitBudget(10, 0)); err == nil {
+		t.Errorf("Expected an error for a zero object weight Got - nil")
+	}
+}
+
+func TestApproachWarning(t *testing.T) {
+	t.Parallel()
+	type warning struct {
+		kind       string
+		found, max int
+	}
+	scenarios := []struct {
+		name     string
+		json     string
+		warnings []warning
+		err      error
+		ok       bool
+	}{
+		{
+			name:     "far from the limits",
+			json:     `[{"a": "xyz"}, 1, 2]`,
+			warnings: nil,
+			err:      nil,
+			ok:       true,
+		},
+		{
+			name: "95% of the array limit",
+			json: `[` + strings.Repeat(`1, `, 18) + `1]`,
+			warnings: []warning{
+				{kind: arrayElementCount, found: 18, max: 20},
+			},
+			err: nil,
+			ok:  true,
+		},
+		{
+			name: "string and depth",
+			json: `[[["abcdefghi"], ["abcdefghij"]]]`,
+			warnings: []warning{
+				{kind: containerDepth, found: 3, max: 3},
+				{kind: stringValueLength, found: 9, max: 10},
+				{kind: containerDepth, found: 3, max: 3},
+				{kind: stringValueLength, found: 10, max: 10},
+			},
+			err: nil,
+			ok:  true,
+		},
+		{
+			name: "warned then failed",
+			json: `[[[["x"]]]]`,
+			warnings: []warning{
+				{kind: containerDepth, found: 3, max: 3},
+			},
+			err: fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[3]-Allowed.Found-[4]"),
+			ok:  false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			var warnings []warning
+			verifier, err := New(WithMaxArrayElementCount(20),
+				WithMaxContainerDepth(3), WithMaxStringLength(10),
+				WithApproachWarning(0.9, func(kind string, found, max int) {
+					warnings = append(warnings, warning{kind, found, max})
+				}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			if fmt.Sprint(warnings) != fmt.Sprint(tc.warnings) {
+				t.Errorf("Expected warnings %v Got %v", tc.warnings, warnings)
+			}
+		})
+	}
+	if _, err := New(WithApproachWarning(1.5, nil)); err == nil {
+		t.Errorf("Expected an error for a fraction above 1")
+	}
+}
+
+func TestMaxArrayLiteralBytes(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayLiteralBytes(64),
+		WithMaxArrayElementCount(10), WithMaxStringLength(1000))
+	large := strings.Repeat("x", 30)
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "within",
+			json: `{"a": ["` + large + `"], "b": [[], [1, 2]]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "few large strings",
+			json: `{"a": ["` + large + `", "` + large + `", "x"]}`,
+			err:  fmt.Errorf("jtp.maxArrayLiteralBytesReached.Max-[64]-Allowed.Found-[73]"),
+			ok:   false,
+		},
+		{
+			name: "nested array fails first",
+			json: `[[["` + large + `", "` + large + `"]]]`,
+			err:  fmt.Errorf("jtp.maxArrayLiteralBytesReached.Max-[64]-Allowed.Found-[68]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxLeadingScalarsInArray(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxLeadingScalarsInArray(2))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "conforming header",
+			json: `["v1", 3, {"a": 1}, 4, 5, 6, [1]]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "scalars only",
+			json: `[1, 2, 3, 4, 5]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "too many leading scalars",
+			json: `[{"a": [1, 2, 3, {}]}]`,
+			err:  fmt.Errorf("jtp.tooManyLeadingScalars.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxArrayRank(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayRank(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "rank 3 matrix",
+			json: `[[[1, 2], [3, 4]], [[5, 6], [7, 8]]]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "rank 4 tensor",
+			json: `[[[[1, 2]], [[3, 4]]]]`,
+			err:  fmt.Errorf("jtp.maxArrayRankReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "object resets the rank",
+			json: `[[{"a": [[[1]]]}], [[2]]]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "rank 4 after an object",
+			json: `[{"a": [1]}, [[[1]]]]`,
+			err:  fmt.Errorf("jtp.maxArrayRankReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRelaxStringLimitForKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(8),
+		WithRelaxStringLimitForKeys("blob", "image"))
+	long := `"aGVsbG8gd29ybGQgaGVsbG8="`
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "long value under allowed key",
+			json: `{"blob": ` + long + `, "x": [{"image": ` + long + `}]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "long value under disallowed key",
+			json: `{"name": ` + long + `}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[24]"),
+			ok:   false,
+		},
+		{
+			name: "nested under allowed key",
+			json: `{"blob": [` + long + `]}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[24]"),
+			ok:   false,
+		},
+		{
+			name: "value after allowed key",
+			json: `{"blob": ` + long + `, "name": ` + long + `}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[24]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			ok, err = verifier.(Verify).VerifyReader(strings.NewReader(tc.json))
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestDepthLimits(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(20), WithDepthLimits(map[int]Limits{
+		1: {ObjectKeyLength: 4, StringValueLen: 5},
+		3: {StringValueLen: 30},
+	}))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "within the limits",
+			json: `{"id": "12345", "body": {"a": {"text": "a long user content text"}}}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "long value at depth 1",
+			json: `{"id": "123456"}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+		{
+			name: "long key at depth 1",
+			json: `{"ident": 1}`,
+			err:  fmt.Errorf("jtp.maxKeyLengthReached.Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "global limits at depth 2",
+			json: `{"body": {"ident": "a long user content t"}}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[20]-Allowed.Found-[21]"),
+			ok:   false,
+		},
+		{
+			name: "long value at depth 3",
+			json: `{"body": [["a user content text longer than"]]}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[30]-Allowed.Found-[31]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			ok, err = verifier.(Verify).VerifyReader(strings.NewReader(tc.json))
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	_, err := New(WithDepthLimits(map[int]Limits{1: {StringValueLen: -1}}))
+	if err == nil {
+		t.Errorf("Expected an error for a negative depth limit")
+	}
+}
+
+func TestContainerDepthLevels(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(2))
+	scenarios := []struct {
+		json  string
+		depth int
+		err   error
+	}{
+		{json: `"scalar"`, depth: 0, err: nil},
+		{json: `[]`, depth: 1, err: nil},
+		{json: `{"a": 1}`, depth: 1, err: nil},
+		{json: `[[]]`, depth: 2, err: nil},
+		{json: `{"a": {"b": [1]}}`, depth: 3,
+			err: fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[2]-Allowed.Found-[3]")},
+		{json: `[[{}]]`, depth: 3,
+			err: fmt.Errorf("jtp.maxContainerDepthReached.Type-[object].Max-[2]-Allowed.Found-[3]")},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.err == nil, ok, tc.err, err)
+			// the observed depth is the real nesting level
+			r, _ := verifier.(Verify).Explain([]byte(tc.json))
+			if len(r.Limits) != 1 || r.Limits[0].Observed != tc.depth {
+				t.Errorf("Expected depth %d Got %+v", tc.depth, r.Limits)
+			}
+		})
+	}
+}
+
+func TestHomogeneousArrays(t *testing.T) {
+	t.Parallel()
+	strict, _ := New(WithHomogeneousArrays())
+	nullable, _ := New(WithNullsInHomogeneousArrays())
+	scenarios := []struct {
+		name     string
+		json     string
+		err      error
+		nullsErr error
+	}{
+		{name: "homogeneous", json: `[1, 2.5, -3e2]`},
+		{name: "empty", json: `{"a": [], "b": [[1, 2], ["x"]]}`},
+		{name: "objects", json: `[{"a": 1}, {"b": "x"}]`},
+		{name: "booleans", json: `[true, false]`},
+		{
+			name:     "mixed",
+			json:     `[1, "two", true]`,
+			err:      fmt.Errorf("jtp.heterogeneousArray.Expected-[number].Got-[string]"),
+			nullsErr: fmt.Errorf("jtp.heterogeneousArray.Expected-[number].Got-[string]"),
+		},
+		{
+			name:     "nested mixed",
+			json:     `{"a": [["x", "y"], [{}, []]]}`,
+			err:      fmt.Errorf("jtp.heterogeneousArray.Expected-[object].Got-[array]"),
+			nullsErr: fmt.Errorf("jtp.heterogeneousArray.Expected-[object].Got-[array]"),
+		},
+		{
+			name: "nulls",
+			json: `[null, "a", null, "b"]`,
+			err:  fmt.Errorf("jtp.heterogeneousArray.Expected-[null].Got-[string]"),
+		},
+		{
+			name:     "nulls then mixed",
+			json:     `[null, "a", null, 1]`,
+			err:      fmt.Errorf("jtp.heterogeneousArray.Expected-[null].Got-[string]"),
+			nullsErr: fmt.Errorf("jtp.heterogeneousArray.Expected-[string].Got-[number]"),
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := strict.VerifyString(tc.json)
+			checkResult(t, tc.err == nil, ok, tc.err, err)
+			ok, err = nullable.VerifyString(tc.json)
+			checkResult(t, tc.nullsErr == nil, ok, tc.nullsErr, err)
+		})
+	}
+}
+
+func TestMaxTrueCount(t *testing.T) {
+	t.Parallel()
+	flags := func(v string, n int) string {
+		return "[" + strings.TrimSuffix(strings.Repeat(v+",", n), ",") + "]"
+	}
+	scenarios := []struct {
+		json  string
+		limit int
+		err   error
+		ok    bool
+	}{
+		{json: flags("true", 3), limit: 3, err: nil, ok: true},
+		{
+			json:  flags("true", 4),
+			limit: 3,
+			err:   fmt.Errorf("jtp.maxTrueCountReached.Max-[3]-Allowed.Found-[4]"),
+			ok:    false,
+		},
+		{json: flags("false", 50), limit: 1, err: nil, ok: true},
+		{
+			json:  `{"a": true, "b": [false, "true", true]}`,
+			limit: 1,
+			err:   fmt.Errorf("jtp.maxTrueCountReached.Max-[1]-Allowed.Found-[2]"),
+			ok:    false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			verifier, _ := New(WithMaxTrueCount(tc.limit))
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithMaxTrueCount(-1)); err == nil {
+		t.Errorf("Expected an error for a negative max true count")
+	}
+}
+
+func TestDisallowNull(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithDisallowNull())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `{"a":null}`, err: fmt.Errorf("jtp.nullValueNotAllowed.Offset-[5]"), ok: false},
+		{json: `[1,null]`, err: fmt.Errorf("jtp.nullValueNotAllowed.Offset-[3]"), ok: false},
+		{json: `null`, err: fmt.Errorf("jtp.nullValueNotAllowed.Offset-[0]"), ok: false},
+		{json: `{"a": [1, "null", false], "b": {}}`, err: nil, ok: true},
+		{json: `[nul]`, err: ErrInvalidJSON, ok: false},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxEmptyValues(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxEmptyValues(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{name: "top level", json: `{}`, err: nil, ok: true},
+		{
+			name: "within the limit",
+			json: `{"a": null, "b": "", "c": [0, false, " "], "d": {}}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "too many empty values",
+			json: `{"a": null, "b": "", "c": {}, "d": [], "e": null}`,
+			err:  fmt.Errorf("jtp.maxEmptyValuesReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+		{
+			name: "array elements",
+			json: `[[null, null], [""], [[]]]`,
+			err:  fmt.Errorf("jtp.maxEmptyValuesReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestArrayLimitOverride(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(2),
+		WithArrayLimitOverride("targets", 5), WithArrayLimitOverride("any", 0))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "large targets",
+			json: `{"targets": [1, 2, 3, 4, 5], "any": [1, 2, 3, 4, 5, 6, 7]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "too large targets",
+			json: `{"targets": [1, 2, 3, 4, 5, 6]}`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[5]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+		{
+			name: "large unrelated array",
+			json: `{"targets": [1], "other": [1, 2, 3]}`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "nested under targets",
+			json: `{"targets": [[1, 2, 3]]}`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "object under targets",
+			json: `{"targets": {"a": [1, 2, 3]}}`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestObjectEntryLimitForKey(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxObjectEntryCount(2),
+		WithObjectEntryLimitForKey("request", 4))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "large request",
+			json: `{"request": {"a": 1, "b": 2, "c": 3, "d": 4}}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "too large request",
+			json: `{"request": {"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}}`,
+			err:  fmt.Errorf("jtp.maxObjectEntryCountReached.Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "large unrelated object",
+			json: `{"request": {}, "other": {"a": 1, "b": 2, "c": 3}}`,
+			err:  fmt.Errorf("jtp.maxObjectEntryCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "nested under request",
+			json: `{"request": {"a": {"x": 1, "y": 2, "z": 3}}}`,
+			err:  fmt.Errorf("jtp.maxObjectEntryCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "array under request",
+			json: `{"request": [{"a": 1, "b": 2, "c": 3}]}`,
+			err:  fmt.Errorf("jtp.maxObjectEntryCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxTokenGap(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxTokenGap(5))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "small gaps",
+			json: `{"a": [1, 2], "b": true}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "long string",
+			json: `{"a": "abcdef"}`,
+			err:  fmt.Errorf("jtp.maxTokenGapReached.Max-[5]-Allowed.Found-[9]"),
+			ok:   false,
+		},
+		{
+			name: "long whitespace",
+			json: `[1,          2]`,
+			err:  fmt.Errorf("jtp.maxTokenGapReached.Max-[5]-Allowed.Found-[11]"),
+			ok:   false,
+		},
+		{
+			name: "leading whitespace",
+			json: `      []`,
+			err:  fmt.Errorf("jtp.maxTokenGapReached.Max-[5]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+		{
+			name: "top level scalar",
+			json: `"abcdefgh"`,
+			err:  fmt.Errorf("jtp.maxTokenGapReached.Max-[5]-Allowed.Found-[10]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	var le *LimitError
+	_, err := verifier.VerifyString(`{"a": "abcdef"}`)
+	if !errors.As(err, &le) || le.Offset != 14 {
+		t.Errorf("Expected the offset 14 of } Got %v", err)
+	}
+}
+
+func TestTrivialFastPath(t *testing.T) {
+	t.Parallel()
+	inputs := []string{"{}", "[]", "null", "true", "false", "0", "42",
+		"-7", "1234567890123456", "{", "[", "tru", "nul", "falsee", "-",
+		"01", "-0", "4.2", "{ }", " 42", "", "12345678901234567"}
+	options := [][]Option{
+		nil,
+		{WithMaxContainerDepth(1), WithMaxArrayElementCount(1)},
+		{WithRequireStructuredTopLevel()},
+		{WithTopLevelMustBeNonEmpty()},
+		{WithMaxNumberDigits(1)},
+		{WithMaxDocumentSize(2)},
+	}
+	for _, opts := range options {
+		verifier, _ := New(opts...)
+		v := verifier.(Verify)
+		for _, in := range inputs {
+			ok, err := v.VerifyString(in)
+			s := state{verifier: v}
+			expOk, expErr := s.verify([]byte(in))
+			checkResult(t, expOk, ok, expErr, err)
+		}
+	}
+	for _, in := range []string{"{", "tru", "01", "{ }", "4.2"} {
+		if isTrivialJSON([]byte(in)) {
+			t.Errorf("Expected %q not to be trivial", in)
+		}
+	}
+}
+
+func TestCountCheckedBeforeValue(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxObjectEntryCount(2),
+		WithMaxArrayElementCount(2))
+	scenarios := []struct {
+		name   string
+		json   string
+		err    error
+		offset int
+	}{
+		{
+			name:   "over-limit entry with malformed value",
+			json:   `{"a": 1, "b": 2, "c": [tru}`,
+			err:    fmt.Errorf("jtp.maxObjectEntryCountReached.Max-[2]-Allowed.Found-[3]"),
+			offset: 17,
+		},
+		{
+			name:   "over-limit element malformed",
+			json:   `[1, 2, {"x" 1}]`,
+			err:    fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			offset: 7,
+		},
+		{
+			name:   "malformed value within the limit",
+			json:   `{"a": 1, "b": [tru, "c": 3}`,
+			err:    ErrInvalidJSON,
+			offset: -1,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, false, ok, tc.err, err)
+			var le *LimitError
+			if errors.As(err, &le) && le.Offset != tc.offset {
+				t.Errorf("Expected offset %d Got %d", tc.offset, le.Offset)
+			}
+		})
+	}
+}
+
+func TestRequiredTopLevelKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRequiredTopLevelKeys("version", "data"))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "both keys",
+			json: `{"data": {"a": 1}, "version": 2, "extra": null}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "missing version",
+			json: `{"data": {"version": 1}}`,
+			err:  fmt.Errorf("jtp.missingRequiredKey.Key-[version]"),
+			ok:   false,
+		},
+		{
+			name: "empty object",
+			json: `{}`,
+			err:  fmt.Errorf("jtp.missingRequiredKey.Key-[version]"),
+			ok:   false,
+		},
+		{
+			name: "top level array",
+			json: `[{"version": 1, "data": 2}]`,
+			err:  fmt.Errorf("jtp.missingRequiredKey.Key-[version]"),
+			ok:   false,
+		},
+		{
+			name: "malformed",
+			json: `{"version": 1, "data": }`,
+			err:  fmt.Errorf("jtp.emptyObjectValue.Offset-[23]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRequiredTopLevelKeysMerged(t *testing.T) {
+	t.Parallel()
+	keys := make([]string, 80)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("b%d", i)
+	}
+	a, _ := New(WithRequiredTopLevelKeys(keys[:40]...))
+	b, _ := New(WithRequiredTopLevelKeys(keys[40:]...))
+	m := Merge(a.(Verify), b.(Verify))
+	json := "{"
+	for i, key := range keys {
+		if i > 0 {
+			json += ", "
+		}
+		json += fmt.Sprintf("%q: %d", key, i)
+	}
+	ok, err := m.VerifyString(json + "}")
+	checkResult(t, true, ok, nil, err)
+	ok, err = m.VerifyString(strings.Replace(json, `"b70"`, `"x"`, 1) + "}")
+	checkResult(t, false, ok,
+		fmt.Errorf("jtp.missingRequiredKey.Key-[b70]"), err)
+}
+
+func TestAllowedTopLevelKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithAllowedTopLevelKeys("version", "data"))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "allowed keys",
+			json: `{"data": {"extra": 1}, "version": 2}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "missing keys",
+			json: `{}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "unexpected key",
+			json: `{"version": 1, "xyz": 2}`,
+			err:  fmt.Errorf("jtp.unexpectedTopLevelKey.Key-[xyz]"),
+			ok:   false,
+		},
+		{
+			name: "escaped key",
+			json: `{"d\u0061ta": 1}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "top level array",
+			json: `[{"xyz": 1}]`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	empty, _ := New(WithAllowedTopLevelKeys())
+	ok, err := empty.VerifyString(`{"a": 1}`)
+	checkResult(t, false, ok,
+		fmt.Errorf("jtp.unexpectedTopLevelKey.Key-[a]"), err)
+}
+
+func TestMaxFlattenedArrayElements(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxFlattenedArrayElements(6))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{name: "flat at the limit", json: `[1, 2, 3, 4, 5, 6]`, err: nil, ok: true},
+		{name: "nested at the limit", json: `[[1, 2, 3], [4, 5, 6]]`, err: nil, ok: true},
+		{
+			name: "objects start a new count",
+			json: `[[1, 2], {"a": [1, 2, 3, 4, 5, 6]}, [3, 4, 5]]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "flat over the limit",
+			json: `[1, 2, 3, 4, 5, 6, 7]`,
+			err:  fmt.Errorf("jtp.maxFlattenedArrayElementsReached.Max-[6]-Allowed.Found-[7]"),
+			ok:   false,
+		},
+		{
+			name: "deeply nested over the limit",
+			json: `[[[1, 2], [3, 4]], [[5, 6], [[7]]]]`,
+			err:  fmt.Errorf("jtp.maxFlattenedArrayElementsReached.Max-[6]-Allowed.Found-[7]"),
+			ok:   false,
+		},
+		{
+			name: "under a key",
+			json: `{"a": [[1], [2]], "b": [[1, 2, 3, 4], [5, 6, 7]]}`,
+			err:  fmt.Errorf("jtp.maxFlattenedArrayElementsReached.Max-[6]-Allowed.Found-[7]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxTableCells(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxTableCells(5000))
+	table := func(rows, cols int) string {
+		var b strings.Builder
+		b.WriteString(`{"rows": [`)
+		for r := 0; r < rows; r++ {
+			if r > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("{")
+			for c := 0; c < cols; c++ {
+				if c > 0 {
+					b.WriteString(", ")
+				}
+				fmt.Fprintf(&b, `"c%d": %d`, c, r)
+			}
+			b.WriteString("}")
+		}
+		b.WriteString("]}")
+		return b.String()
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{name: "50 x 100", json: table(50, 100), err: nil, ok: true},
+		{
+			name: "100 x 100",
+			json: table(100, 100),
+			err:  fmt.Errorf("jtp.maxTableCellsReached.Max-[5000]-Allowed.Found-[5100]"),
+			ok:   false,
+		},
+		{
+			name: "widening row",
+			json: `[{"a": 1}, {"a": 1, "b": 2}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{name: "array of scalars", json: `[` + strings.Repeat(`1, `, 6000) + `1]`, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	verifier, _ = New(WithMaxTableCells(4))
+	ok, err := verifier.VerifyString(`[{"a": 1}, {"a": 1}, {"a": 1, "b": 2, "c": 3}]`)
+	checkResult(t, false, ok,
+		fmt.Errorf("jtp.maxTableCellsReached.Max-[4]-Allowed.Found-[9]"), err)
+}
+
+func TestAllowlist(t *testing.T) {
+	t.Parallel()
+	health := []byte(`{"status": "ok", "checks": [1, 2, 3]}`)
+	walks := 0
+	verifier, _ := New(WithMaxArrayElementCount(2),
+		WithAllowlist([][]byte{health, []byte(`{}`)}),
+		WithApproachWarning(0.01, func(kind string, found, max int) {
+			walks++
+		}))
+	// allowlisted although it breaks the array limit
+	ok, err := verifier.VerifyBytes(health)
+	checkResult(t, true, ok, nil, err)
+	if walks != 0 {
+		t.Errorf("Expected the allowlisted document not to be walked")
+	}
+
+	nearMiss := []byte(`{"status": "ok", "checks": [1, 2, 4]}`)
+	ok, err = verifier.VerifyBytes(nearMiss)
+	checkResult(t, false, ok, fmt.Errorf(
+		"jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"), err)
+	if walks == 0 {
+		t.Errorf("Expected the near-miss document to be walked")
+	}
+
+	pool, _ := NewPool(WithMaxArrayElementCount(2),
+		WithAllowlist([][]byte{health}))
+	ok, err = pool.VerifyBytes(health)
+	checkResult(t, true, ok, nil, err)
+	ok, err = pool.VerifyBytes(nearMiss)
+	checkResult(t, false, ok, fmt.Errorf(
+		"jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"), err)
+}
+
+func TestSkipValidation(t *testing.T) {
+	t.Parallel()
+	skip, _ := New(WithSkipStringValidation(), WithSkipNumberValidation())
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "invalid escapes and controls",
+			json: "[\"a\\qb\", \"\\uZZ\"Z\", \"\t\x01\"]",
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "invalid numbers",
+			json: `[01, 1-2, 1e, -]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "unterminated string",
+			json: `["abc\"]`,
+			err:  ErrInvalidJSON,
+			ok:   false,
+		},
+		{
+			name: "invalid key",
+			json: `{"\q": 1}`,
+			err:  ErrInvalidJSON,
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := skip.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	// the number content checks need the grammar
+	canonical, _ := New(WithSkipNumberValidation(), WithCanonicalNumbers())
+	ok, err := canonical.VerifyString(`[1-2]`)
+	checkResult(t, false, ok, ErrInvalidJSON, err)
+
+	// the metrics of a valid json are unaffected
+	json := _getTestJSONBytes()
+	full := Verify{}
+	wantHist, _, _ := full.TypeHistogram(json)
+	gotHist, ok, err := skip.(Verify).TypeHistogram(json)
+	checkResult(t, true, ok, nil, err)
+	if gotHist != wantHist {
+		t.Errorf("Expected histogram %+v Got %+v", wantHist, gotHist)
+	}
+	wantDepth, _, _ := full.MaxDepth(json)
+	gotDepth, _, _ := skip.(Verify).MaxDepth(json)
+	if gotDepth != wantDepth {
+		t.Errorf("Expected depth %d Got %d", wantDepth, gotDepth)
+	}
+}
+
+func TestQuickReject(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithQuickReject())
+	for _, json := range []string{string(_getTestJSONBytes()), ` {"a": [1]} `,
+		"[]\n", `"a"`, `""`, `-0`, `1.5e+10`, ` true`, `false `, `null`} {
+		ok, err := verifier.VerifyString(json)
+		checkResult(t, true, ok, nil, err)
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+	}{
+		{
+			name: "truncated object",
+			json: `{"a": [1, 2` + strings.Repeat(", 3", 1000),
+			err:  fmt.Errorf("jtp.quickReject.FirstByte-[{].LastByte-[3]"),
+		},
+		{
+			name: "truncated string",
+			json: ` "abc `,
+			err:  fmt.Errorf("jtp.quickReject.FirstByte-[\"].LastByte-[c]"),
+		},
+		{
+			name: "lone double quote",
+			json: `"`,
+			err:  fmt.Errorf("jtp.quickReject.FirstByte-[\"].LastByte-[\"]"),
+		},
+		{
+			name: "trailing garbage",
+			json: `[1]x`,
+			err:  fmt.Errorf("jtp.quickReject.FirstByte-[[].LastByte-[x]"),
+		},
+		{name: "blank", json: " \n", err: ErrEmptyInput},
+		{
+			name: "not json",
+			json: `<html>`,
+			err:  fmt.Errorf("jtp.notJSON.FirstByte-[<]"),
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, false, ok, tc.err, err)
+		})
+	}
+	// passing the quick check doesn't make a json valid
+	ok, err := verifier.VerifyString(`{"a": [}`)
+	checkResult(t, false, ok, ErrInvalidJSON, err)
+}
+
+func TestDepthExemptKey(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(2), WithDepthExemptKey("deep"))
 	scenarios := []struct {
-		str []byte
-		err error
+		name string
+		json string
+		err  error
+		ok   bool
 	}{
 		{
-			str: []byte("Hello, 世界"),
-			err: nil,
+			name: "deep under exempt key",
+			json: `{"deep": {"a": [{"b": {"c": 1}}]}, "x": [1]}`,
+			err:  nil,
+			ok:   true,
 		},
 		{
-			str: []byte(`i ♥ u`),
-			err: nil,
+			name: "deep under escaped exempt key",
+			json: `{"d\u0065ep": {"a": [{"b": {"c": 1}}]}}`,
+			err:  nil,
+			ok:   true,
 		},
 		{
-			str: []byte(`"Hello, World!"`),
-			err: fmt.Errorf("jtp.maxStringValueLengthReached.Max-[10]-Allowed.Found-[13]"),
+			name: "deep elsewhere",
+			json: `{"other": {"a": [{"b": {"c": 1}}]}}`,
+			err:  fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "deep after exempt key",
+			json: `{"deep": {"a": [1]}, "other": {"a": [1]}}`,
+			err:  fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
 		},
 	}
-
 	for _, tc := range scenarios {
-		t.Run(string(tc.str), func(t *testing.T) {
-			e := validateStringLength(tc.str, 0, len(tc.str),
-				true, maxAllowed, stringValueLength)
-			if tc.err == nil && e != nil {
-				t.Errorf("Expected an nil error Got - %v", e)
-			}
-			if tc.err != nil && e == nil {
-				t.Errorf("Expected an not nil error Got - nil")
-			}
-			if tc.err != nil && e != nil && e.Error() != tc.err.Error() {
-				t.Errorf("Expected error to be %s Got %s", tc.err.Error(), e.Error())
-			}
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
 		})
 	}
 }
@@ -175,10 +2572,9 @@ func TestIsValidArrayCase1(t *testing.T) {
 		MaxArrayElementCount:   maxChild,
 		arrayEntryCountEnabled: true,
 	}
-	var depth int
 	for _, tc := range scenarios {
 		t.Run(tc.name, func(t *testing.T) {
-			_, ok, err := isValidArray(tc.arr, 1, &depth, &verifier)
+			_, ok, err := isValidArray(tc.arr, 1, &state{verifier: verifier})
 			if tc.ok != ok {
 				t.Errorf("Expected validation %v Got %v", tc.ok, ok)
 			}
@@ -193,6 +2589,159 @@ func TestIsValidArrayCase1(t *testing.T) {
 	}
 }
 
+func TestArrayElementCountBoundary(t *testing.T) {
+	t.Parallel()
+	for _, n := range []int{1, 2, 5, 10} {
+		n := n
+		t.Run(fmt.Sprintf("max %d", n), func(t *testing.T) {
+			verifier, _ := New(WithMaxArrayElementCount(n),
+				WithMaxObjectEntryCount(n))
+			arr := func(l int) string {
+				return "[" + strings.TrimSuffix(strings.Repeat("1,", l), ",") + "]"
+			}
+			obj := func(l int) string {
+				var b strings.Builder
+				b.WriteString("{")
+				for i := 0; i < l; i++ {
+					if i > 0 {
+						b.WriteString(",")
+					}
+					fmt.Fprintf(&b, `"k%d":1`, i)
+				}
+				b.WriteString("}")
+				return b.String()
+			}
+			ok, err := verifier.VerifyString(arr(n))
+			checkResult(t, true, ok, nil, err)
+			ok, err = verifier.VerifyString(arr(n + 1))
+			checkResult(t, false, ok, fmt.Errorf(
+				"jtp.maxArrayElementCountReached.Max-[%d]-Allowed.Found-[%d]",
+				n, n+1), err)
+			ok, err = verifier.VerifyString(obj(n))
+			checkResult(t, true, ok, nil, err)
+			ok, err = verifier.VerifyString(obj(n + 1))
+			checkResult(t, false, ok, fmt.Errorf(
+				"jtp.maxObjectEntryCountReached.Max-[%d]-Allowed.Found-[%d]",
+				n, n+1), err)
+		})
+	}
+}
+
+func TestArrayElementCountCommasInValues(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "strings with commas",
+			json: `["a,b,c", ",", ",,,"]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "escaped quotes and commas",
+			json: `["a\",b", "\\,", "c"]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "objects with commas in strings",
+			json: `[{"a": "x,y", "b": ","}, {"c,d": 1}, {}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "nested arrays",
+			json: `[[1, 2, 3], [4, [5, 6, 7]], []]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "top level commas only",
+			json: `["a,b", [1, 2, 3], {"a": 1, "b": 2}, "c,d"]`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[3]-Allowed.Found-[4]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestArrayElementCountShortCircuit(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(2),
+		WithMaxStringLength(3))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "overflowing element malformed",
+			json: `["a", "b", tru]`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "overflowing element too long",
+			json: `["a", "b", "abcdef"]`,
+			err:  fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:   false,
+		},
+		{
+			name: "element error is not lost",
+			json: `["abcdef", "a"]`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[3]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxTopLevelKeyLength(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxTopLevelKeyLength(5))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "long top level key",
+			json: `{"long_key": {"a": 1}}`,
+			err:  fmt.Errorf("jtp.maxTopLevelKeyLengthReached.Max-[5]-Allowed.Found-[8]"),
+			ok:   false,
+		},
+		{
+			name: "long nested key",
+			json: `{"a": {"long_key": 1}, "b": [{"long_key": 1}]}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
 func TestIsValidObjectCase1(t *testing.T) {
 	t.Parallel()
 	b := _getTestJSONBytes()
@@ -244,7 +2793,7 @@ func TestIsValidObjectCase1(t *testing.T) {
 				jsonContainerDepthEnabled: true,
 				JSONContainerDepth:        2,
 			},
-			err: fmt.Errorf("jtp.maxContainerDepthReached.Max-[2]-Allowed.Found-[3]"),
+			err: fmt.Errorf("jtp.maxContainerDepthReached.Type-[object].Max-[2]-Allowed.Found-[3]"),
 			ok:  false,
 		},
 		{
@@ -253,7 +2802,7 @@ func TestIsValidObjectCase1(t *testing.T) {
 				jsonContainerDepthEnabled: true,
 				JSONContainerDepth:        5,
 			},
-			err: fmt.Errorf("jtp.maxContainerDepthReached.Max-[5]-Allowed.Found-[6]"),
+			err: fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[5]-Allowed.Found-[6]"),
 			ok:  false,
 		},
 		{
@@ -269,8 +2818,7 @@ func TestIsValidObjectCase1(t *testing.T) {
 
 	for _, tc := range scenarios {
 		t.Run(tc.name, func(t *testing.T) {
-			var depth int
-			_, ok, err := isValidObject(b, 1, &depth, &tc.verifier)
+			_, ok, err := isValidObject(b, 1, &state{verifier: tc.verifier})
 			if tc.ok != ok {
 				t.Errorf("Expected validation %v Got %v", tc.ok, ok)
 			}
@@ -338,7 +2886,7 @@ func TestTestifyNoJSONThreatInBytesErrorCase(t *testing.T) {
 				jsonContainerDepthEnabled: true,
 				JSONContainerDepth:        2,
 			},
-			err: fmt.Errorf("jtp.maxContainerDepthReached.Max-[2]-Allowed.Found-[3]"),
+			err: fmt.Errorf("jtp.maxContainerDepthReached.Type-[object].Max-[2]-Allowed.Found-[3]"),
 			ok:  false,
 		},
 		{
@@ -347,7 +2895,7 @@ func TestTestifyNoJSONThreatInBytesErrorCase(t *testing.T) {
 				jsonContainerDepthEnabled: true,
 				JSONContainerDepth:        5,
 			},
-			err: fmt.Errorf("jtp.maxContainerDepthReached.Max-[5]-Allowed.Found-[6]"),
+			err: fmt.Errorf("jtp.maxContainerDepthReached.Type-[object].Max-[5]-Allowed.Found-[6]"),
 			ok:  false,
 		},
 	}
@@ -383,6 +2931,250 @@ func TestTestifyNoJSONThreatInBytesErrorCase2(t *testing.T) {
 
 }
 
+func TestEmptyInput(t *testing.T) {
+	t.Parallel()
+	v := Verify{}
+	scenarios := []struct {
+		name string
+		json []byte
+	}{
+		{name: "empty", json: []byte("")},
+		{name: "whitespace", json: []byte("   ")},
+		{name: "nil", json: nil},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := v.VerifyBytes(tc.json)
+			if ok != false || err != ErrEmptyInput {
+				t.Errorf("Expected Ok to Be False and Error of kind ErrEmptyInput Got %v %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestRequireStructuredTopLevel(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRequireStructuredTopLevel())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `"hello"`, err: ErrBareTopLevelValue, ok: false},
+		{json: ` 42`, err: ErrBareTopLevelValue, ok: false},
+		{json: `true`, err: ErrBareTopLevelValue, ok: false},
+		{json: `{}`, err: nil, ok: true},
+		{json: ` [] `, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestTopLevelMustBeNonEmpty(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithTopLevelMustBeNonEmpty())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `{}`, err: ErrEmptyTopLevelContainer, ok: false},
+		{json: ` [ ] `, err: ErrEmptyTopLevelContainer, ok: false},
+		{json: `{"a":1}`, err: nil, ok: true},
+		{json: `[1]`, err: nil, ok: true},
+		{json: `[{}, []]`, err: nil, ok: true},
+		{json: `"bare"`, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxScanBudget(t *testing.T) {
+	t.Parallel()
+	padding := strings.Repeat(" ", 100)
+	scenarios := []struct {
+		name    string
+		json    string
+		options []Option
+		err     error
+		ok      bool
+	}{
+		{
+			name:    "within the budget",
+			json:    `[1, "abc"]` + padding,
+			options: []Option{WithMaxScanBudget(110)},
+			err:     nil,
+			ok:      true,
+		},
+		{
+			name:    "whitespace padded",
+			json:    `[1,` + padding + `2]`,
+			options: []Option{WithMaxScanBudget(50)},
+			err:     fmt.Errorf("jtp.scanBudgetExceeded.Max-[50]-Allowed.Found-[103]"),
+			ok:      false,
+		},
+		{
+			name:    "trailing whitespace",
+			json:    `[1]` + padding,
+			options: []Option{WithMaxScanBudget(50)},
+			err:     fmt.Errorf("jtp.scanBudgetExceeded.Max-[50]-Allowed.Found-[103]"),
+			ok:      false,
+		},
+		{
+			name:    "rescanned strings",
+			json:    `["abc", "abc"]`,
+			options: []Option{WithMaxScanBudget(14), WithMaxStringLength(10)},
+			err:     fmt.Errorf("jtp.scanBudgetExceeded.Max-[14]-Allowed.Found-[23]"),
+			ok:      false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier, _ := New(tc.options...)
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestASCIIOnly(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithASCIIOnly())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `{"name": "hello", "n": [1, true, null]}`, err: nil, ok: true},
+		{json: `["caf\u00e9"]`, err: nil, ok: true},
+		{
+			json: `{"name": "世界"}`,
+			err:  fmt.Errorf("jtp.nonASCIIByte.Offset-[10].Byte-[0xe4]"),
+			ok:   false,
+		},
+		{
+			json: `{"é": 1}`,
+			err:  fmt.Errorf("jtp.nonASCIIByte.Offset-[2].Byte-[0xc3]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestTolerateRawControlInStrings(t *testing.T) {
+	t.Parallel()
+	strict, _ := New()
+	relaxed, _ := New(WithTolerateRawControlInStrings())
+	scenarios := []struct {
+		name    string
+		json    string
+		relaxed bool
+	}{
+		{name: "raw newline", json: "{\"a\": \"line1\nline2\"}", relaxed: true},
+		{name: "raw tab", json: "[\"a\tb\"]", relaxed: true},
+		{name: "raw NUL", json: "[\"a\x00b\"]", relaxed: false},
+		{name: "raw carriage return", json: "[\"a\rb\"]", relaxed: false},
+		{name: "raw newline in key", json: "{\"a\nb\": 1}", relaxed: false},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, _ := strict.VerifyString(tc.json)
+			if ok {
+				t.Errorf("Expected strict validation false Got true")
+			}
+			ok, _ = relaxed.VerifyString(tc.json)
+			if ok != tc.relaxed {
+				t.Errorf("Expected relaxed validation %v Got %v", tc.relaxed, ok)
+			}
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	t.Parallel()
+	scenarios := []string{
+		``, ` `, `null`, `true`, `false`, `nul`, `tru`, `fals`, `truex`,
+		`0`, `-0`, `-`, `[-]`, `-.5`, `{"a":-}`, `-a`, `01`, `-01`, `1.`,
+		`.1`, `1.5`, `1e`, `1e+`,
+		`1E-2`, `1e+10`, `-1.5e10`, `+1`, `0x1F`, `NaN`, `Infinity`,
+		`""`, `"\x"`, `"\u12"`, `"\u12g4"`, `"\u00e9"`, `"\ud800"`,
+		`"\uD83D\uDE00"`, "\"\t\"", "\"\x7f\"", `"a`, `"\"`,
+		`[]`, `[1,]`, `[,1]`, `[1 2]`, `[1,2]`, `[[[]]]`, `[`,
+		`{}`, `{"a":1}`, `{"a":1,}`, `{"a" 1}`, `{"a":}`, `{1:1}`,
+		`{"a":1}{}`, `{"a":[1,{"b":null}]}`, ` {"a" : "b" } `,
+	}
+	for _, tc := range scenarios {
+		t.Run(tc, func(t *testing.T) {
+			if expected := json.Valid([]byte(tc)); IsValidString(tc) != expected {
+				t.Errorf("Expected validation %v Got %v", expected, !expected)
+			}
+		})
+	}
+	if !IsValid(_getTestJSONBytes()) || IsValid(_getMalformedTestJSONBytes()) {
+		t.Errorf("Unexpected validation of the test JSON")
+	}
+}
+
+func TestNotJSON(t *testing.T) {
+	t.Parallel()
+	v := Verify{}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+	}{
+		{
+			name: "xml",
+			json: `<?xml version="1.0"?><note><to>Tove</to></note>`,
+			err:  fmt.Errorf("jtp.notJSON.FirstByte-[<]"),
+		},
+		{
+			name: "html",
+			json: "\n  <!DOCTYPE html><html><body></body></html>",
+			err:  fmt.Errorf("jtp.notJSON.FirstByte-[<]"),
+		},
+		{
+			name: "text",
+			json: `hello`,
+			err:  fmt.Errorf("jtp.notJSON.FirstByte-[h]"),
+		},
+		{
+			name: "malformed literal",
+			json: `nope`,
+			err:  ErrInvalidJSON,
+		},
+		{
+			name: "closing brace",
+			json: ` }`,
+			err:  fmt.Errorf("jtp.unexpectedByte.Offset-[1]"),
+		},
+		{
+			name: "leading comma",
+			json: `,1]`,
+			err:  fmt.Errorf("jtp.unexpectedByte.Offset-[0]"),
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := v.VerifyString(tc.json)
+			checkResult(t, false, ok, tc.err, err)
+		})
+	}
+}
+
 func TestTestifyNoJSONThreatInBytesPositiveCase1(t *testing.T) {
 	t.Parallel()
 	b := _getTestJSONBytes()
@@ -450,6 +3242,56 @@ func BenchmarkTestifyNoThreatInBytes(b *testing.B) {
 	}
 }
 
+func BenchmarkLongKeys(b *testing.B) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i := 0; i < 100; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `"%s_%03d": %d`, strings.Repeat("key♥", 64), i, i)
+	}
+	buf.WriteByte('}')
+	json := buf.Bytes()
+	verifier, _ := New(WithMaxObjectKeyLength(1024),
+		WithMaxTopLevelKeyLength(1024))
+	b.SetBytes(int64(len(json)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		verifier.VerifyBytes(json)
+	}
+}
+
+func BenchmarkSkipValidation(b *testing.B) {
+	json := _getTestJSONBytes()
+	full, _ := New()
+	skip, _ := New(WithSkipStringValidation(), WithSkipNumberValidation())
+	for name, verifier := range map[string]Verifier{"full": full,
+		"skip": skip} {
+		verifier := verifier
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(json)))
+			for i := 0; i < b.N; i++ {
+				verifier.VerifyBytes(json)
+			}
+		})
+	}
+}
+
+// checkResult compares the verification result with the expected one.
+func checkResult(t *testing.T, expOk, ok bool, expErr, err error) {
+	t.Helper()
+	if expOk != ok {
+		t.Errorf("Expected validation %v Got %v", expOk, ok)
+	}
+	if expErr == nil && err != nil {
+		t.Errorf("Expected an nil error Got - %v", err)
+	}
+	if expErr != nil && (err == nil || err.Error() != expErr.Error()) {
+		t.Errorf("Expected error to be %s Got %v", expErr.Error(), err)
+	}
+}
+
 func _getTestJSONBytes() []byte {
 	return []byte(`{
 	"simple_string": "hello word",
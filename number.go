@@ -0,0 +1,248 @@
+package gojtp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrScientificNotation denotes a number with an exponent part
+// while only plain decimal numbers are allowed.
+var ErrScientificNotation = errors.New("jtp.scientificNotationNotAllowed")
+
+// validateNumberContent applies the enabled checks on the value
+// of a valid number token starting at offset.
+func validateNumberContent(token []byte, offset int,
+	verifier *Verify) (err error) {
+	if verifier.PlainDecimalNumbersOnly && bytes.IndexAny(token, "eE") >= 0 {
+		return ErrScientificNotation
+	}
+	if verifier.CanonicalNumbers && !isCanonicalNumber(token) {
+		return fmt.Errorf("jtp.nonCanonicalNumber.Token-[%s]", token)
+	}
+	if verifier.exponentDigitsEnabled {
+		if n := countExponentDigits(token); n > verifier.MaxExponentDigits {
+			return newLimitError(exponentDigits,
+				verifier.MaxExponentDigits, n, offset)
+		}
+	}
+	if verifier.numberDigitsEnabled {
+		if n := countDigits(token); n > verifier.MaxNumberDigits {
+			return newLimitError(numberDigits,
+				verifier.MaxNumberDigits, n, offset)
+		}
+	}
+	if verifier.bareDigitsEnabled &&
+		countSignificantDigits(token) > verifier.MaxBareDigits {
+		return fmt.Errorf("jtp.largeNumberNotStringified.Token-[%s]", token)
+	}
+	if verifier.RejectLossyNumbers && isLossyNumber(token) {
+		return fmt.Errorf("jtp.lossyNumber.Token-[%s]", token)
+	}
+	if verifier.RejectUnderflowNumbers && isUnderflowNumber(token) {
+		return fmt.Errorf("jtp.numberUnderflow.Token-[%s]", token)
+	}
+	if verifier.RejectOverflowNumbers && isOverflowNumber(token) {
+		return fmt.Errorf("jtp.numberOverflow.Token-[%s]", token)
+	}
+	if verifier.RejectNegativeZero && token[0] == '-' && isZeroNumber(token) {
+		return fmt.Errorf("jtp.negativeZeroNotAllowed.Token-[%s]", token)
+	}
+	if verifier.Int64RangeNumbers && isOutOfInt64Range(token) {
+		return fmt.Errorf("jtp.int64RangeExceeded.Token-[%s]", token)
+	}
+	if verifier.numberValidator != nil {
+		if err = verifier.numberValidator(token); err != nil {
+			return &RejectedError{Kind: "number", Offset: offset, Err: err}
+		}
+	}
+	return
+}
+
+// isCanonicalNumber reports if the valid number token is in the
+// canonical form of WithCanonicalNumbers.
+func isCanonicalNumber(token []byte) bool {
+	if token[0] == '-' {
+		token = token[1:]
+		if len(token) == 1 && token[0] == '0' {
+			return false
+		}
+	}
+	mantissa, exp := token, []byte(nil)
+	e := bytes.IndexAny(token, "eE")
+	if e >= 0 {
+		mantissa, exp = token[:e], token[e+1:]
+	}
+	dot := bytes.IndexByte(mantissa, '.')
+	if dot >= 0 && mantissa[len(mantissa)-1] == '0' {
+		return false
+	}
+	if e < 0 {
+		return true
+	}
+	// normalized scientific notation
+	if token[e] == 'E' || mantissa[0] == '0' || dot > 1 ||
+		(dot < 0 && len(mantissa) > 1) || exp[0] == '+' {
+		return false
+	}
+	if exp[0] == '-' {
+		exp = exp[1:]
+	}
+	return exp[0] != '0'
+}
+
+// countExponentDigits returns the number of digits in the exponent
+// part of a valid number token.
+func countExponentDigits(token []byte) int {
+	e := bytes.IndexAny(token, "eE")
+	if e < 0 {
+		return 0
+	}
+	exp := token[e+1:]
+	if exp[0] == '+' || exp[0] == '-' {
+		exp = exp[1:]
+	}
+	return len(exp)
+}
+
+// countDigits returns the number of digits of a number token.
+func countDigits(token []byte) (n int) {
+	for _, c := range token {
+		if c >= '0' && c <= '9' {
+			n++
+		}
+	}
+	return
+}
+
+// countSignificantDigits returns the number of integer and fraction
+// digits of a number token, without the leading zeros.
+func countSignificantDigits(token []byte) (n int) {
+	for _, c := range token {
+		if c == 'e' || c == 'E' {
+			break
+		}
+		if c >= '1' && c <= '9' || c == '0' && n > 0 {
+			n++
+		}
+	}
+	return
+}
+
+// isUnderflowNumber reports if a non zero number token
+// parses to a zero float64.
+func isUnderflowNumber(token []byte) bool {
+	f, _ := strconv.ParseFloat(string(token), 64)
+	return f == 0 && !isZeroNumber(token)
+}
+
+// isOverflowNumber reports if the number token is out of
+// the float64 range.
+func isOverflowNumber(token []byte) bool {
+	_, err := strconv.ParseFloat(string(token), 64)
+	return errors.Is(err, strconv.ErrRange)
+}
+
+// isOutOfInt64Range reports if the number token is an integer,
+// without fraction or exponent, out of the int64 range.
+func isOutOfInt64Range(token []byte) bool {
+	if bytes.IndexAny(token, ".eE") >= 0 {
+		return false
+	}
+	_, err := strconv.ParseInt(string(token), 10, 64)
+	return err != nil
+}
+
+// isZeroNumber reports if all the digits before the exponent
+// of the number token are zero.
+func isZeroNumber(token []byte) bool {
+	for _, c := range token {
+		if c == 'e' || c == 'E' {
+			break
+		}
+		if c >= '1' && c <= '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isLossyNumber reports if the decimal value of the number token
+// differs from the shortest formatting of its float64 value.
+func isLossyNumber(token []byte) bool {
+	f, err := strconv.ParseFloat(string(token), 64)
+	if err != nil {
+		return true
+	}
+	return normalizeNumber(token) !=
+		normalizeNumber(strconv.AppendFloat(nil, f, 'e', -1, 64))
+}
+
+// decimal is a normalized number, digits without leading
+// or trailing zeros, scaled by 10^exp.
+type decimal struct {
+	neg    bool
+	digits string
+	exp    int
+}
+
+// maxExponent bounds the parsed exponent of a number.
+const maxExponent = 1 << 30
+
+// normalizeNumber converts a valid number token to a decimal,
+// so that equal values compare equal.
+func normalizeNumber(token []byte) (d decimal) {
+	i := 0
+	if token[i] == '-' {
+		d.neg = true
+		i++
+	}
+	digits := make([]byte, 0, len(token))
+	frac := false
+	for ; i < len(token); i++ {
+		c := token[i]
+		if c == '.' {
+			frac = true
+			continue
+		}
+		if c == 'e' || c == 'E' {
+			break
+		}
+		digits = append(digits, c)
+		if frac {
+			d.exp--
+		}
+	}
+	if i < len(token) {
+		i++
+		neg := false
+		if token[i] == '+' || token[i] == '-' {
+			neg = token[i] == '-'
+			i++
+		}
+		exp := 0
+		for ; i < len(token); i++ {
+			if exp < maxExponent {
+				exp = exp*10 + int(token[i]-'0')
+			}
+		}
+		if neg {
+			exp = -exp
+		}
+		d.exp += exp
+	}
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	for len(digits) > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		d.exp++
+	}
+	if len(digits) == 0 {
+		// zero, regardless of sign and exponent
+		return decimal{}
+	}
+	d.digits = string(digits)
+	return
+}
@@ -0,0 +1,395 @@
+package gojtp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRejectLossyNumbers(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectLossyNumbers())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		// 0.1 is not exact in binary, but its shortest
+		// float64 formatting round-trips to 0.1.
+		{json: `[0.1]`, err: nil, ok: true},
+		{json: `[1.50, 1e3, -0, 0.0e10, 9007199254740992]`, err: nil, ok: true},
+		{
+			json: `[9007199254740993]`,
+			err:  fmt.Errorf("jtp.lossyNumber.Token-[9007199254740993]"),
+			ok:   false,
+		},
+		{
+			json: `{"a": 0.12345678901234567890}`,
+			err:  fmt.Errorf("jtp.lossyNumber.Token-[0.12345678901234567890]"),
+			ok:   false,
+		},
+		{
+			json: `[1e400]`,
+			err:  fmt.Errorf("jtp.lossyNumber.Token-[1e400]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRejectUnderflowNumbers(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectUnderflowNumbers())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `[0]`, err: nil, ok: true},
+		{json: `[-0.0e-400, 0e10]`, err: nil, ok: true},
+		{json: `[1e-300]`, err: nil, ok: true},
+		{
+			json: `[1e-400]`,
+			err:  fmt.Errorf("jtp.numberUnderflow.Token-[1e-400]"),
+			ok:   false,
+		},
+		{
+			json: `{"a": -0.000001e-320}`,
+			err:  fmt.Errorf("jtp.numberUnderflow.Token-[-0.000001e-320]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestPlainDecimalNumbersOnly(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithPlainDecimalNumbersOnly())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `[100, -0.5, 0]`, err: nil, ok: true},
+		{json: `[1e3]`, err: ErrScientificNotation, ok: false},
+		{json: `{"id": 2.5E-1}`, err: ErrScientificNotation, ok: false},
+		{json: `[0x1F]`, err: ErrInvalidJSON, ok: false},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestCanonicalNumbers(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithCanonicalNumbers())
+	canonical := `[1.5, 100, 0, -7, 0.5, -0.25, 1.5e10, 2e-7, 9.99e300]`
+	ok, err := verifier.VerifyString(canonical)
+	checkResult(t, true, ok, nil, err)
+	for _, token := range []string{"1.50", "1.0", "-0", "-0.0", "1e0",
+		"15e9", "1.5E10", "1.5e+10", "1.5e010", "0.5e3", "1.20e5"} {
+		t.Run(token, func(t *testing.T) {
+			ok, err := verifier.VerifyString(`[` + token + `]`)
+			checkResult(t, false, ok,
+				fmt.Errorf("jtp.nonCanonicalNumber.Token-[%s]", token), err)
+		})
+	}
+}
+
+func TestRequireStringifiedLargeNumbers(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRequireStringifiedLargeNumbers(15))
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `[12345, -0.000123, 1.5e300, 0]`, err: nil, ok: true},
+		{json: `{"id": "12345678901234567890"}`, err: nil, ok: true},
+		{json: `[123456789012345, 0.00123456789012345]`, err: nil, ok: true},
+		{
+			json: `{"id": 12345678901234567890}`,
+			err:  fmt.Errorf("jtp.largeNumberNotStringified.Token-[12345678901234567890]"),
+			ok:   false,
+		},
+		{
+			json: `[1234567890.123456]`,
+			err:  fmt.Errorf("jtp.largeNumberNotStringified.Token-[1234567890.123456]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRejectOverflowNumbers(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectOverflowNumbers())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `[1.7e308, 0, 1e-400]`, err: nil, ok: true},
+		{
+			json: `[1e309]`,
+			err:  fmt.Errorf("jtp.numberOverflow.Token-[1e309]"),
+			ok:   false,
+		},
+		{
+			json: `{"a": -1e400}`,
+			err:  fmt.Errorf("jtp.numberOverflow.Token-[-1e400]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestRejectNegativeZero(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithRejectNegativeZero())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `-0`, err: fmt.Errorf("jtp.negativeZeroNotAllowed.Token-[-0]"), ok: false},
+		{json: `[-0.0]`, err: fmt.Errorf("jtp.negativeZeroNotAllowed.Token-[-0.0]"), ok: false},
+		{json: `{"a": -0e5}`, err: fmt.Errorf("jtp.negativeZeroNotAllowed.Token-[-0e5]"), ok: false},
+		{json: `0`, err: nil, ok: true},
+		{json: `[0.0, -1, -0.01, 1e-0]`, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxExponentDigits(t *testing.T) {
+	t.Parallel()
+	scenarios := []struct {
+		json  string
+		limit int
+		err   error
+		ok    bool
+	}{
+		{json: `[1e12345]`, limit: 5, err: nil, ok: true},
+		{json: `[1e12345, 2]`, limit: 6, err: nil, ok: true},
+		{
+			json:  `[1e12345]`,
+			limit: 4,
+			err:   fmt.Errorf("jtp.maxExponentDigitsReached.Max-[4]-Allowed.Found-[5]"),
+			ok:    false,
+		},
+		{
+			json:  `{"a": -0.5E-00012345}`,
+			limit: 5,
+			err:   fmt.Errorf("jtp.maxExponentDigitsReached.Max-[5]-Allowed.Found-[8]"),
+			ok:    false,
+		},
+		{json: `[123456789.123456789, 1E+9]`, limit: 1, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			verifier, _ := New(WithMaxExponentDigits(tc.limit))
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxNumberDigits(t *testing.T) {
+	t.Parallel()
+	scenarios := []struct {
+		json  string
+		limit int
+		err   error
+		ok    bool
+	}{
+		{json: `[-1.5e10]`, limit: 4, err: nil, ok: true},
+		{
+			json:  `[-1.5e10]`,
+			limit: 3,
+			err:   fmt.Errorf("jtp.maxNumberDigitsReached.Max-[3]-Allowed.Found-[4]"),
+			ok:    false,
+		},
+		{
+			json:  `{"a": 1, "b": 1234567890}`,
+			limit: 9,
+			err:   fmt.Errorf("jtp.maxNumberDigitsReached.Max-[9]-Allowed.Found-[10]"),
+			ok:    false,
+		},
+		{json: `{"a": 1, "b": 1234567890}`, limit: 10, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			verifier, _ := New(WithMaxNumberDigits(tc.limit))
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestMaxFloatCount(t *testing.T) {
+	t.Parallel()
+	ints := "[" + strings.TrimSuffix(strings.Repeat("12, -3, ", 10), ", ") + "]"
+	scenarios := []struct {
+		json  string
+		limit int
+		err   error
+		ok    bool
+	}{
+		{json: ints, limit: 1, err: nil, ok: true},
+		{json: `[1.5, 2, 3e2, 4]`, limit: 2, err: nil, ok: true},
+		{
+			json:  `[1.5, 2, 3e2, 4, -0.5E-1]`,
+			limit: 2,
+			err:   fmt.Errorf("jtp.maxFloatCountReached.Max-[2]-Allowed.Found-[3]"),
+			ok:    false,
+		},
+		{
+			json:  `{"a": 0.1, "b": {"c": [0.2]}}`,
+			limit: 1,
+			err:   fmt.Errorf("jtp.maxFloatCountReached.Max-[1]-Allowed.Found-[2]"),
+			ok:    false,
+		},
+		{json: `{"a": "0.1", "b": "1e3"}`, limit: 1, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			verifier, _ := New(WithMaxFloatCount(tc.limit))
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithMaxFloatCount(-1)); err == nil {
+		t.Errorf("Expected an error for a negative max float count")
+	}
+}
+
+func TestMaxTotalNumberBytes(t *testing.T) {
+	t.Parallel()
+	numbers := "[" + strings.TrimSuffix(strings.Repeat("123.45, ", 20), ", ") + "]"
+	scenarios := []struct {
+		json  string
+		limit int
+		err   error
+		ok    bool
+	}{
+		{json: numbers, limit: 120, err: nil, ok: true},
+		{
+			json:  numbers,
+			limit: 100,
+			err:   fmt.Errorf("jtp.maxTotalNumberBytesReached.Max-[100]-Allowed.Found-[102]"),
+			ok:    false,
+		},
+		{json: `{"a": "1234567890", "b": -1e5}`, limit: 4, err: nil, ok: true},
+		{
+			json:  `42`,
+			limit: 1,
+			err:   fmt.Errorf("jtp.maxTotalNumberBytesReached.Max-[1]-Allowed.Found-[2]"),
+			ok:    false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			verifier, _ := New(WithMaxTotalNumberBytes(tc.limit))
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithMaxTotalNumberBytes(-1)); err == nil {
+		t.Errorf("Expected an error for a negative max total number bytes")
+	}
+}
+
+func TestNumberValidator(t *testing.T) {
+	t.Parallel()
+	errMultipleOf7 := errors.New("multiple of 7")
+	verifier, _ := New(WithNumberValidator(func(token []byte) error {
+		n, err := strconv.Atoi(string(token))
+		if err == nil && n%7 == 0 {
+			return errMultipleOf7
+		}
+		return nil
+	}))
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `[1, 2.5, 13, -8, 7e1]`, err: nil, ok: true},
+		{
+			json: `{"a": 1, "b": -21}`,
+			err:  fmt.Errorf("jtp.numberRejected.Offset-[14].Reason-[multiple of 7]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			if tc.err != nil && !errors.Is(err, errMultipleOf7) {
+				t.Errorf("Expected the error to wrap the validator error")
+			}
+		})
+	}
+	if _, err := New(WithNumberValidator(nil)); err == nil {
+		t.Errorf("Expected an error for a nil validator")
+	}
+}
+
+func TestInt64RangeNumbers(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithInt64RangeNumbers())
+	scenarios := []struct {
+		json string
+		err  error
+		ok   bool
+	}{
+		{json: `[9223372036854775807, -9223372036854775808]`, err: nil, ok: true},
+		{
+			json: `[9223372036854775808]`,
+			err:  fmt.Errorf("jtp.int64RangeExceeded.Token-[9223372036854775808]"),
+			ok:   false,
+		},
+		{
+			json: `{"a": -9223372036854775809}`,
+			err:  fmt.Errorf("jtp.int64RangeExceeded.Token-[-9223372036854775809]"),
+			ok:   false,
+		},
+		{json: `[9223372036854775808.5, 1e30]`, err: nil, ok: true},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.json, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package gojtp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotPatchDocument denotes JSON Patch is not an array of operations
+var ErrNotPatchDocument = errors.New("jtp.notPatchDocument")
+
+const (
+	patchOp    uint8 = 1 << iota // "op" member seen
+	patchPath                    // "path" member seen
+	patchValue                   // "value" member seen
+	patchFrom                    // "from" member seen
+)
+
+// patchRequired lists the members required by each operation.
+// https://tools.ietf.org/html/rfc6902#section-4
+var patchRequired = map[string]uint8{
+	"add":     patchPath | patchValue,
+	"remove":  patchPath,
+	"replace": patchPath | patchValue,
+	"move":    patchPath | patchFrom,
+	"copy":    patchPath | patchFrom,
+	"test":    patchPath | patchValue,
+}
+
+// patchHandler checks the walked JSON is a RFC 6902 JSON Patch.
+type patchHandler struct {
+	depth int
+	// index of the current operation
+	index int
+	// member of the current operation whose value is next
+	member uint8
+	seen   uint8
+	op     []byte
+}
+
+func (p *patchHandler) invalid() error {
+	return fmt.Errorf("jtp.invalidPatchOperation.Index-[%d]", p.index)
+}
+
+func (p *patchHandler) begin(c byte, i int) error {
+	p.depth++
+	switch p.depth {
+	case 1:
+		if c != '[' {
+			return ErrNotPatchDocument
+		}
+	case 2:
+		p.index++
+		if c != '{' {
+			return p.invalid()
+		}
+		p.seen, p.op = 0, p.op[:0]
+	case 3:
+		// only the value member can be a container
+		if p.member != patchValue {
+			return p.invalid()
+		}
+		p.member = 0
+	}
+	return nil
+}
+
+func (p *patchHandler) end(c byte, i int) error {
+	p.depth--
+	if p.depth != 1 {
+		return nil
+	}
+	required, ok := patchRequired[string(p.op)]
+	if p.seen&patchOp == 0 || !ok || p.seen&required != required {
+		return p.invalid()
+	}
+	return nil
+}
+
+func (p *patchHandler) key(data []byte, startIndex, endIndex int) error {
+	if p.depth != 2 {
+		return nil
+	}
+	p.member = 0
+	switch string(appendDecodedString(nil, data, startIndex, endIndex)) {
+	case "op":
+		p.member = patchOp
+	case "path":
+		p.member = patchPath
+	case "value":
+		p.member = patchValue
+	case "from":
+		p.member = patchFrom
+	}
+	p.seen |= p.member
+	return nil
+}
+
+func (p *patchHandler) value(data []byte, startIndex, endIndex int) error {
+	switch p.depth {
+	case 0:
+		return ErrNotPatchDocument
+	case 1:
+		p.index++
+		return p.invalid()
+	case 2:
+		member := p.member
+		p.member = 0
+		if member == patchOp || member == patchPath ||
+			member == patchFrom {
+			if data[startIndex] != '"' {
+				return p.invalid()
+			}
+			if member == patchOp {
+				p.op = appendDecodedString(p.op[:0], data, startIndex,
+					endIndex)
+			}
+		}
+	}
+	return nil
+}
+
+// VerifyJSONPatch returns true if the input is valid json,
+// is JSON THREAT Protection Safe and is a well-formed
+// RFC 6902 JSON Patch: an array of operation objects, each with
+// a known op and its required path, value or from members.
+func (v Verify) VerifyJSONPatch(json []byte) (bool, error) {
+	s := state{verifier: v, h: &patchHandler{index: -1}}
+	return s.verify(json)
+}
@@ -0,0 +1,80 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVerifyJSONPatch(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(5))
+	v := verifier.(Verify)
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "valid patch",
+			json: `[
+				{ "op": "test", "path": "/a/b/c", "value": "foo" },
+				{ "op": "remove", "path": "/a/b/c" },
+				{ "op": "add", "path": "/a/b/c", "value": [ "foo", "bar" ] },
+				{ "op": "replace", "path": "/a/b/c", "value": 42 },
+				{ "op": "move", "from": "/a/b/c", "path": "/a/b/d" },
+				{ "op": "copy", "from": "/a/b/d", "path": "/a/b/e" }
+			]`,
+			err: nil,
+			ok:  true,
+		},
+		{
+			name: "missing op",
+			json: `[{ "op": "remove", "path": "/a" }, { "path": "/a", "value": 1 }]`,
+			err:  fmt.Errorf("jtp.invalidPatchOperation.Index-[1]"),
+			ok:   false,
+		},
+		{
+			name: "unknown op",
+			json: `[{ "op": "delete", "path": "/a" }]`,
+			err:  fmt.Errorf("jtp.invalidPatchOperation.Index-[0]"),
+			ok:   false,
+		},
+		{
+			name: "missing value",
+			json: `[{ "op": "add", "path": "/a" }]`,
+			err:  fmt.Errorf("jtp.invalidPatchOperation.Index-[0]"),
+			ok:   false,
+		},
+		{
+			name: "path not a string",
+			json: `[{ "op": "remove", "path": ["a"] }]`,
+			err:  fmt.Errorf("jtp.invalidPatchOperation.Index-[0]"),
+			ok:   false,
+		},
+		{
+			name: "operation not an object",
+			json: `[{ "op": "remove", "path": "/a" }, "remove"]`,
+			err:  fmt.Errorf("jtp.invalidPatchOperation.Index-[1]"),
+			ok:   false,
+		},
+		{
+			name: "not an array",
+			json: `{ "op": "remove", "path": "/a" }`,
+			err:  ErrNotPatchDocument,
+			ok:   false,
+		},
+		{
+			name: "limits still apply",
+			json: `[{ "op": "add", "path": "/a", "value": [[[[1]]]] }]`,
+			err:  fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[5]-Allowed.Found-[6]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := v.VerifyJSONPatch([]byte(tc.json))
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package gojtp
+
+import (
+	"errors"
+	"strconv"
+)
+
+// pathTracker tracks the location of the current value of a walk
+// from its events, to be embedded by the handlers needing it.
+type pathTracker struct {
+	// frames of the open containers, reused to keep the key buffers.
+	frames []pathFrame
+	n      int
+}
+
+// pathFrame is the current key or index within an open container.
+type pathFrame struct {
+	array bool
+	index int
+	key   []byte
+}
+
+// next moves to the next value of the current container.
+func (t *pathTracker) next() {
+	if t.n > 0 && t.frames[t.n-1].array {
+		t.frames[t.n-1].index++
+	}
+}
+
+func (t *pathTracker) begin(c byte) {
+	t.next()
+	t.push(c)
+}
+
+// push opens the container c at the current location.
+func (t *pathTracker) push(c byte) {
+	if t.n == len(t.frames) {
+		t.frames = append(t.frames, pathFrame{})
+	}
+	f := &t.frames[t.n]
+	f.array, f.index, f.key = c == '[', -1, f.key[:0]
+	t.n++
+}
+
+func (t *pathTracker) end() {
+	t.n--
+}
+
+func (t *pathTracker) key(data []byte, startIndex, endIndex int) {
+	f := &t.frames[t.n-1]
+	f.key = appendDecodedString(f.key[:0], data, startIndex, endIndex)
+}
+
+// appendPointer appends the RFC 6901 JSON Pointer of the current
+// value to dst.
+func (t *pathTracker) appendPointer(dst []byte) []byte {
+	return t.appendTemplate(dst, false)
+}
+
+// appendTemplate appends the JSON Pointer of the current value to
+// dst, with * for the array indices if wildcard.
+func (t *pathTracker) appendTemplate(dst []byte, wildcard bool) []byte {
+	for _, f := range t.frames[:t.n] {
+		dst = append(dst, '/')
+		if f.array && wildcard {
+			dst = append(dst, '*')
+			continue
+		}
+		if f.array {
+			dst = strconv.AppendInt(dst, int64(f.index), 10)
+			continue
+		}
+		for _, c := range f.key {
+			switch c {
+			case '~':
+				dst = append(dst, '~', '0')
+			case '/':
+				dst = append(dst, '~', '1')
+			default:
+				dst = append(dst, c)
+			}
+		}
+	}
+	return dst
+}
+
+// pathForwarder is a forwarder tracking the location of the current
+// value, to be embedded by the handlers reporting it.
+type pathForwarder struct {
+	pathTracker
+	forwarder
+}
+
+func (f *pathForwarder) begin(c byte, i int) error {
+	f.pathTracker.begin(c)
+	return f.forwarder.begin(c, i)
+}
+
+func (f *pathForwarder) end(c byte, i int) error {
+	f.pathTracker.end()
+	return f.forwarder.end(c, i)
+}
+
+func (f *pathForwarder) key(data []byte, startIndex, endIndex int) error {
+	f.pathTracker.key(data, startIndex, endIndex)
+	return f.forwarder.key(data, startIndex, endIndex)
+}
+
+func (f *pathForwarder) value(data []byte, startIndex, endIndex int) error {
+	f.pathTracker.next()
+	return f.forwarder.value(data, startIndex, endIndex)
+}
+
+// errPathFound stops the walk of a pointerHandler.
+var errPathFound = errors.New("jtp.pathFound")
+
+// pointerTarget is what a pointerHandler looks for at its offset.
+type pointerTarget int
+
+const (
+	// the container whose content starts at the offset
+	targetContainer pointerTarget = iota
+	// the object holding the key which starts at the offset
+	targetKey
+	// the scalar value which starts at the offset
+	targetValue
+)
+
+// pointerHandler finds the path of the on target at the offset target.
+type pointerHandler struct {
+	pathTracker
+	target int
+	on     pointerTarget
+}
+
+func (h *pointerHandler) begin(c byte, i int) error {
+	h.pathTracker.begin(c)
+	if h.on == targetContainer && i+1 == h.target {
+		return errPathFound
+	}
+	return nil
+}
+
+func (h *pointerHandler) end(c byte, i int) error {
+	h.pathTracker.end()
+	return nil
+}
+
+func (h *pointerHandler) key(data []byte, startIndex, endIndex int) error {
+	if h.on == targetKey && startIndex == h.target {
+		return errPathFound
+	}
+	h.pathTracker.key(data, startIndex, endIndex)
+	return nil
+}
+
+func (h *pointerHandler) value(data []byte, startIndex, endIndex int) error {
+	h.next()
+	if h.on == targetValue && startIndex == h.target {
+		return errPathFound
+	}
+	return nil
+}
+
+// containerPointer returns the JSON Pointer of the container whose
+// content starts at offset in json, by walking json again without
+// limits up to it, so the path is only computed on failure.
+func containerPointer(json []byte, offset int) string {
+	return walkPointer(json, &pointerHandler{target: offset})
+}
+
+// objectPointer returns the JSON Pointer of the object holding the
+// key which starts at offset in json, see containerPointer.
+func objectPointer(json []byte, offset int) string {
+	return walkPointer(json, &pointerHandler{target: offset,
+		on: targetKey})
+}
+
+// valuePointer returns the JSON Pointer of the scalar value which
+// starts at offset in json, see containerPointer.
+func valuePointer(json []byte, offset int) string {
+	return walkPointer(json, &pointerHandler{target: offset,
+		on: targetValue})
+}
+
+func walkPointer(json []byte, h *pointerHandler) string {
+	s := state{h: h}
+	if _, err := s.verify(json); err != errPathFound {
+		return ""
+	}
+	if h.on != targetValue {
+		// the path of the container within its parent
+		h.pathTracker.end()
+	}
+	return string(h.appendPointer(nil))
+}
@@ -0,0 +1,116 @@
+package gojtp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContainerDepthPath(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(3))
+	scenarios := []struct {
+		name string
+		json string
+		path string
+	}{
+		{name: "arrays", json: `[1, [2, [3, [4]]]]`, path: "/1/1/1"},
+		{
+			name: "objects and arrays",
+			json: `{"a": 1, "b/c": [{"x": 1}, {"y": {"z": 1}}]}`,
+			path: "/b~1c/1/y",
+		},
+		{
+			name: "fixture",
+			json: `{"users": [{"name": "a"}, {"tags": ["t", {"deep": 1}]}]}`,
+			path: "/users/1/tags",
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.VerifyString(tc.json)
+			var le *LimitError
+			if !errors.As(err, &le) || le.Kind != containerDepth {
+				t.Fatalf("Expected a container depth error Got %v", err)
+			}
+			if le.Path != tc.path {
+				t.Errorf("Expected path %q Got %q", tc.path, le.Path)
+			}
+		})
+	}
+}
+
+func TestObjectEntryCountPath(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxObjectEntryCount(2))
+	scenarios := []struct {
+		name string
+		json string
+		path string
+	}{
+		{name: "root", json: `{"a": 1, "b": 2, "c": 3}`, path: ""},
+		{
+			name: "nested",
+			json: `{"a": {"x": 1, "y": 2}, "b": [{"x": 1}, {"x": 1, "y": 2, "z": 3}]}`,
+			path: "/b/1",
+		},
+		{
+			name: "escaped key",
+			json: `[{"a/b": {"~": {"x": 1, "y": 2, "z": 3}}}]`,
+			path: "/0/a~1b/~0",
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.VerifyString(tc.json)
+			var le *LimitError
+			if !errors.As(err, &le) || le.Kind != objectEntryCount {
+				t.Fatalf("Expected an object entry count error Got %v", err)
+			}
+			if le.Path != tc.path {
+				t.Errorf("Expected path %q Got %q", tc.path, le.Path)
+			}
+		})
+	}
+}
+
+func TestStringLengthPath(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxObjectKeyLength(5), WithMaxStringLength(5))
+	scenarios := []struct {
+		name string
+		json string
+		kind string
+		path string
+	}{
+		{
+			name: "long key",
+			json: `{"a": [1, {"b": 1, "abcdef": "x"}]}`,
+			kind: objectKeyValueLength,
+			path: "/a/1",
+		},
+		{
+			name: "long value",
+			json: `{"a": [1, {"b": 1, "abcde": "abcdef"}]}`,
+			kind: stringValueLength,
+			path: "/a/1/abcde",
+		},
+		{
+			name: "long array value",
+			json: `{"a": [1, ["x", "abcdef"]]}`,
+			kind: stringValueLength,
+			path: "/a/1/1",
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.VerifyString(tc.json)
+			var le *LimitError
+			if !errors.As(err, &le) || le.Kind != tc.kind {
+				t.Fatalf("Expected a %s error Got %v", tc.kind, err)
+			}
+			if le.Path != tc.path {
+				t.Errorf("Expected path %q Got %q", tc.path, le.Path)
+			}
+		})
+	}
+}
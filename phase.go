@@ -0,0 +1,137 @@
+package gojtp
+
+// VerifyStructure returns true if the json is valid json and passes
+// the structural limits of v: the container depth, counts and
+// states, the document size, the decoded memory estimate and the
+// structured top level. The string and number content checks are
+// skipped, so it is cheap enough for the request path.
+//
+// VerifyBytes remains the combined one-pass path, VerifyStructure
+// and VerifyContent together apply the same checks in two passes.
+func (v Verify) VerifyStructure(json []byte) (bool, error) {
+	return v.structure().VerifyBytes(json)
+}
+
+// VerifyContent returns true if the json is valid json and passes
+// the content checks of v on the keys, strings and numbers,
+// the structural limits are skipped, see VerifyStructure.
+func (v Verify) VerifyContent(json []byte) (bool, error) {
+	return v.content().VerifyBytes(json)
+}
+
+// structure returns a copy of v with only the structural limits.
+func (v Verify) structure() Verify {
+	v.objectKeyLengthEnabled = false
+	v.keyByteLengthEnabled = false
+	v.topLevelKeyLengthEnabled = false
+	v.keyUTF16UnitsEnabled = false
+	v.totalKeyBytesEnabled = false
+	v.stringLenEnabled = false
+	v.stringWeightEnabled = false
+	v.stringUTF16UnitsEnabled = false
+	v.DepthLimits = nil
+	v.versionedLimits = nil
+	v.RejectReplacementChar = false
+	v.RejectNullCharInStrings = false
+	v.RejectNoncharacters = false
+	v.RejectZeroWidthChars = false
+	v.RejectBidiControls = false
+	v.RejectExtendedControls = false
+	v.ForwardSlashPolicy = SlashAllow
+	v.identicalRepeatsEnabled = false
+	v.valueFractionEnabled = false
+	v.keyRepeatsEnabled = false
+	v.embeddedJSONEnabled = false
+	v.FlagEmbeddedJSON = false
+	v.entropyEnabled = false
+	v.charRunEnabled = false
+	v.ASCIIOnly = false
+	v.KeysMustBeIdentifiers = false
+	v.RejectNumericKeys = false
+	v.RejectCoercibleKeyCollisions = false
+	v.consecutiveEscapesEnabled = false
+	v.scriptsEnabled = false
+	v.combiningMarksEnabled = false
+	v.totalEscapesEnabled = false
+	v.stringValueCharset = nil
+	v.stringValidator = nil
+	v.URLKeys = nil
+	v.stringFormats = nil
+	v.epochRanges = nil
+	v.typeSchema = nil
+	v.discriminators = nil
+	v.uniformValues = nil
+	v.sortedArrays = nil
+	v.RequiredTopLevelKeys = nil
+	v.allowedTopLevelKeysEnabled = false
+	v.keyValueRatioEnabled = false
+	v.HomogeneousArrays = false
+	v.UniformObjectKeysInArrays = false
+	v.DisallowNull = false
+	v.trueCountEnabled = false
+	v.RejectLossyNumbers = false
+	v.RejectUnderflowNumbers = false
+	v.RejectOverflowNumbers = false
+	v.RejectNegativeZero = false
+	v.Int64RangeNumbers = false
+	v.PlainDecimalNumbersOnly = false
+	v.CanonicalNumbers = false
+	v.exponentDigitsEnabled = false
+	v.numberValidator = nil
+	v.numberDigitsEnabled = false
+	v.bareDigitsEnabled = false
+	v.floatCountEnabled = false
+	v.totalNumberBytesEnabled = false
+	return v
+}
+
+// content returns a copy of v with only the content checks.
+func (v Verify) content() Verify {
+	v.arrayEntryCountEnabled = false
+	v.ArrayLimitOverrides = nil
+	v.arrayUnitBudgetEnabled = false
+	v.leadingScalarsEnabled = false
+	v.arrayLiteralBytesEnabled = false
+	v.jsonContainerDepthEnabled = false
+	v.arrayRankEnabled = false
+	v.flattenedElementsEnabled = false
+	v.tableCellsEnabled = false
+	v.objectEntryCountEnabled = false
+	v.ObjectEntryLimitOverrides = nil
+	v.subtreeEntriesEnabled = false
+	v.emptyValuesEnabled = false
+	v.cumulativeEntriesEnabled = false
+	v.cumulativeBytesEnabled = false
+	v.streamTopLevelKeysEnabled = false
+	v.containerCountEnabled = false
+	v.totalObjectsEnabled = false
+	v.objectFillEnabled = false
+	v.structuralStatesEnabled = false
+	v.transitionsEnabled = false
+	v.consecutiveOpensEnabled = false
+	v.bytesPerChildEnabled = false
+	v.documentSizeEnabled = false
+	v.colonWhitespaceEnabled = false
+	v.decodedMemoryEnabled = false
+	v.scanBudgetEnabled = false
+	v.tokenGapEnabled = false
+	v.tokenBytesEnabled = false
+	v.canonicalSizeEnabled = false
+	v.RequireStructuredTopLevel = false
+	v.TopLevelMustBeNonEmpty = false
+	return v
+}
+
+// VerifyBytesWellFormed verifies the json as VerifyBytes does and
+// returns the first error, but when a limit or content check fails
+// the json is still validated to the end without any limit, so the
+// returned wellFormed tells apart a malformed json from a valid json
+// which breaks the policy of v. A nil error means the json passed.
+func (v Verify) VerifyBytesWellFormed(json []byte) (wellFormed bool,
+	err error) {
+	ok, err := v.VerifyBytes(json)
+	if ok {
+		return true, nil
+	}
+	return IsValid(json), err
+}
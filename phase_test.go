@@ -0,0 +1,81 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVerifyPhases(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(2), WithMaxArrayElementCount(3),
+		WithMaxStringLength(5), WithMaxObjectKeyLength(3),
+		WithRejectLossyNumbers())
+	v := verifier.(Verify)
+	depthErr := fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[2]-Allowed.Found-[3]")
+	countErr := fmt.Errorf("jtp.maxArrayElementCountReached.Max-[3]-Allowed.Found-[4]")
+	stringErr := fmt.Errorf("jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[6]")
+	keyErr := fmt.Errorf("jtp.maxKeyLengthReached.Max-[3]-Allowed.Found-[4]")
+	numberErr := fmt.Errorf("jtp.lossyNumber.Token-[9007199254740993]")
+	scenarios := []struct {
+		name       string
+		json       string
+		structErr  error
+		contentErr error
+	}{
+		{name: "valid", json: `{"a": ["abc", 1]}`},
+		{name: "depth", json: `[[[1]]]`, structErr: depthErr},
+		{name: "count", json: `[1, 2, 3, 4]`, structErr: countErr},
+		{name: "string", json: `["abcdef"]`, contentErr: stringErr},
+		{name: "key", json: `{"abcd": 1}`, contentErr: keyErr},
+		{name: "number", json: `[9007199254740993]`, contentErr: numberErr},
+		{
+			name:       "malformed",
+			json:       `[1, 2`,
+			structErr:  ErrInvalidJSON,
+			contentErr: ErrInvalidJSON,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := v.VerifyStructure([]byte(tc.json))
+			checkResult(t, tc.structErr == nil, ok, tc.structErr, err)
+			ok, err = v.VerifyContent([]byte(tc.json))
+			checkResult(t, tc.contentErr == nil, ok, tc.contentErr, err)
+			expErr := tc.structErr
+			if expErr == nil {
+				expErr = tc.contentErr
+			}
+			ok, err = v.VerifyBytes([]byte(tc.json))
+			checkResult(t, expErr == nil, ok, expErr, err)
+		})
+	}
+}
+
+func TestVerifyBytesWellFormed(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(3))
+	v := verifier.(Verify)
+	countErr := fmt.Errorf("jtp.maxArrayElementCountReached.Max-[3]-Allowed.Found-[4]")
+	scenarios := []struct {
+		name       string
+		json       string
+		err        error
+		wellFormed bool
+	}{
+		{name: "valid", json: `[1, 2, 3]`, wellFormed: true},
+		{name: "policy", json: `[1, 2, 3, 4]`, err: countErr, wellFormed: true},
+		{
+			name:       "policy then malformed",
+			json:       `{"a": [1, 2, 3, 4], "b": [1,]}`,
+			err:        countErr,
+			wellFormed: false,
+		},
+		{name: "malformed", json: `[1,]`, err: &MalformedError{Reason: trailingComma, Offset: 2}},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			wellFormed, err := v.VerifyBytesWellFormed([]byte(tc.json))
+			checkResult(t, tc.wellFormed, wellFormed, tc.err, err)
+		})
+	}
+}
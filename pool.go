@@ -0,0 +1,53 @@
+package gojtp
+
+import (
+	"io"
+	"sync"
+)
+
+// VerifierPool is a Verifier safe for concurrent use that reuses
+// the scratch state of the validations, like the structural states
+// bitset, across calls.
+type VerifierPool struct {
+	verifier Verify
+	states   sync.Pool
+}
+
+// NewPool creates and return a VerifierPool with passed Option
+// Parameters, like New.
+func NewPool(opts ...Option) (*VerifierPool, error) {
+	v, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	p := &VerifierPool{verifier: v.(Verify)}
+	p.states.New = func() interface{} {
+		return &state{}
+	}
+	return p, nil
+}
+
+// VerifyBytes returns true if the input is valid json,
+// and is JSON THREAT Protection Safe, see Verify.VerifyBytes.
+func (p *VerifierPool) VerifyBytes(json []byte) (bool, error) {
+	if p.verifier.allowlisted(json) {
+		return true, nil
+	}
+	s := p.states.Get().(*state)
+	*s = state{verifier: p.verifier, states: s.states[:0]}
+	ok, err := s.verify(json)
+	p.states.Put(s)
+	return ok, err
+}
+
+// VerifyString returns true if the input is valid json,
+// and is JSON THREAT Protection Safe, see Verify.VerifyString.
+func (p *VerifierPool) VerifyString(json string) (bool, error) {
+	return p.VerifyBytes([]byte(json))
+}
+
+// VerifyReader returns true if the json read from r is valid json,
+// and is JSON THREAT Protection Safe, see Verify.VerifyReader.
+func (p *VerifierPool) VerifyReader(r io.Reader) (bool, error) {
+	return p.verifier.VerifyReader(r)
+}
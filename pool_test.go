@@ -0,0 +1,75 @@
+package gojtp
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestVerifierPoolConcurrent(t *testing.T) {
+	t.Parallel()
+	pool, err := NewPool(WithMaxContainerDepth(7),
+		WithMaxStructuralStates(8), WithMaxStringLength(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := _getTestJSONBytes()
+	deep := []byte(`[[[[[[[[1]]]]]]]]`)
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				ok, err := pool.VerifyBytes(valid)
+				checkResult(t, true, ok, nil, err)
+				ok, err = pool.VerifyBytes(deep)
+				checkResult(t, false, ok, fmt.Errorf(
+					"jtp.maxContainerDepthReached.Type-[array].Max-[7]-Allowed.Found-[8]"),
+					err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := NewPool(WithMaxContainerDepth(-1)); err == nil {
+		t.Errorf("Expected an error for a negative option Got - nil")
+	}
+}
+
+func BenchmarkVerifierPoolParallel(b *testing.B) {
+	json := _getTestJSONBytes()
+	pool, _ := NewPool(WithMaxArrayElementCount(6),
+		WithMaxContainerDepth(7),
+		WithMaxObjectKeyLength(20), WithMaxStringLength(50),
+		WithMaxObjectEntryCount(5), WithMaxStructuralStates(16))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			pool.VerifyBytes(json)
+		}
+	})
+}
+
+// BenchmarkVerifyBatch verifies many small documents in a row, the
+// pooled scratch state being cleared and reused between them.
+func BenchmarkVerifyBatch(b *testing.B) {
+	docs := make([][]byte, 1000)
+	for i := range docs {
+		docs[i] = []byte(fmt.Sprintf(
+			`{"id": %d, "tags": ["a", "b"], "meta": {"ok": true}}`, i))
+	}
+	pool, _ := NewPool(WithMaxContainerDepth(7),
+		WithMaxObjectEntryCount(5), WithMaxStringLength(50),
+		WithMaxStructuralStates(16))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, doc := range docs {
+			if ok, err := pool.VerifyBytes(doc); !ok {
+				b.Fatal(err)
+			}
+		}
+	}
+}
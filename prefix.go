@@ -0,0 +1,29 @@
+package gojtp
+
+// VerifyPrefix verifies the json as VerifyBytes does and returns the
+// number of leading bytes forming a valid and policy compliant
+// partial structure, and if they hold a complete value, e.g. to
+// recover the part of a corrupted log before the corruption.
+//
+// A truncated json, cut anywhere, returns all its bytes and a nil
+// error with complete false. Otherwise the error is the one of
+// VerifyBytes and validBytes is where it was found: the offset of
+// the failed limit, or the byte the parser stopped at. A complete
+// value followed by extra data returns complete true with the error.
+func (v Verify) VerifyPrefix(json []byte) (validBytes int, complete bool,
+	err error) {
+	s := state{verifier: v}
+	ok, err := s.verify(json)
+	if ok {
+		return len(json), true, nil
+	}
+	validBytes = s.end
+	if le, isLimit := err.(*LimitError); isLimit {
+		validBytes = le.Offset
+	}
+	if validBytes >= len(json) && (err == ErrInvalidJSON ||
+		err == ErrEmptyInput) {
+		return len(json), false, nil
+	}
+	return validBytes, IsValid(json[:validBytes]), err
+}
@@ -0,0 +1,66 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVerifyPrefix(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(3))
+	doc := `{"a": [1, -2.5e3, "hé\"llo"], "b": {"c": false, "d": null}}`
+	// every cut of the document is a valid prefix
+	for n := 0; n < len(doc); n++ {
+		validBytes, complete, err := verifier.(Verify).
+			VerifyPrefix([]byte(doc[:n]))
+		if validBytes != n || complete || err != nil {
+			t.Errorf("%q Expected {%d, false, <nil>} Got {%d, %v, %v}",
+				doc[:n], n, validBytes, complete, err)
+		}
+	}
+	scenarios := []struct {
+		name       string
+		json       string
+		validBytes int
+		complete   bool
+		err        error
+	}{
+		{name: "complete", json: doc, validBytes: len(doc), complete: true},
+		{
+			name:       "corrupted",
+			json:       `{"a": [1, 2 x 3]}`,
+			validBytes: 12,
+			err:        ErrInvalidJSON,
+		},
+		{
+			name:       "corrupted literal",
+			json:       `[true, fa1se]`,
+			validBytes: 9,
+			err:        ErrInvalidJSON,
+		},
+		{
+			name:       "extra data",
+			json:       `{"a": 1} {"b"`,
+			validBytes: 9,
+			complete:   true,
+			err:        ErrInvalidJSON,
+		},
+		{
+			name:       "limit",
+			json:       `[1, 2, 3, 4]`,
+			validBytes: 10,
+			err:        fmt.Errorf("jtp.maxArrayElementCountReached.Max-[3]-Allowed.Found-[4]"),
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			validBytes, complete, err := verifier.(Verify).
+				VerifyPrefix([]byte(tc.json))
+			if validBytes != tc.validBytes || complete != tc.complete {
+				t.Errorf("Expected {%d, %v} Got {%d, %v}", tc.validBytes,
+					tc.complete, validBytes, complete)
+			}
+			checkResult(t, false, false, tc.err, err)
+		})
+	}
+}
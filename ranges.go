@@ -0,0 +1,41 @@
+package gojtp
+
+// stringRangesHandler records the Range of each string value.
+type stringRangesHandler struct {
+	ranges []Range
+}
+
+func (h *stringRangesHandler) begin(c byte, i int) error {
+	return nil
+}
+
+func (h *stringRangesHandler) end(c byte, i int) error {
+	return nil
+}
+
+func (h *stringRangesHandler) key(data []byte, startIndex, endIndex int) error {
+	return nil
+}
+
+func (h *stringRangesHandler) value(data []byte, startIndex, endIndex int) error {
+	if data[startIndex] == '"' {
+		h.ranges = append(h.ranges, Range{Start: startIndex, End: endIndex})
+	}
+	return nil
+}
+
+// VerifyWithStringRanges is like VerifyBytes and also returns the
+// Range of each string value, quotes included, in the order of the
+// json, e.g. to mask them before logging the json. The keys are not
+// string values.
+//
+// When the json fails, the ranges are the ones of the string values
+// which passed before the failure, so the remaining bytes must be
+// masked too.
+func (v Verify) VerifyWithStringRanges(json []byte) (ranges []Range,
+	ok bool, err error) {
+	h := &stringRangesHandler{}
+	s := state{verifier: v, h: h}
+	ok, err = s.verify(json)
+	return h.ranges, ok, err
+}
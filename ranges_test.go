@@ -0,0 +1,47 @@
+package gojtp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyWithStringRanges(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(8))
+	v := verifier.(Verify)
+
+	json := `{"name": "ana", "tags": ["a\"b", 1, true, {"k": ""}],
+		"age": 3, "note": "été"}`
+	ranges, ok, err := v.VerifyWithStringRanges([]byte(json))
+	checkResult(t, true, ok, nil, err)
+	var found []string
+	for _, r := range ranges {
+		found = append(found, json[r.Start:r.End])
+	}
+	expected := []string{`"ana"`, `"a\"b"`, `""`, `"été"`}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %q Got %q", expected, found)
+	}
+
+	t.Run("top level string", func(t *testing.T) {
+		ranges, ok, err := v.VerifyWithStringRanges([]byte(` "x" `))
+		checkResult(t, true, ok, nil, err)
+		if expected := []Range{{Start: 1, End: 4}}; !reflect.DeepEqual(
+			ranges, expected) {
+			t.Errorf("Expected %v Got %v", expected, ranges)
+		}
+	})
+
+	t.Run("partial ranges", func(t *testing.T) {
+		json := `["short", "too long string", "unseen"]`
+		ranges, ok, err := v.VerifyWithStringRanges([]byte(json))
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[15]"),
+			err)
+		if expected := []Range{{Start: 1, End: 8}}; !reflect.DeepEqual(
+			ranges, expected) {
+			t.Errorf("Expected %v Got %v", expected, ranges)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package gojtp
+
+import (
+	"fmt"
+	"math"
+)
+
+// WithMaxKeyValueLengthRatio Option
+// Specifies the maximum ratio of the byte length of an object value
+// to the byte length of its key, both as written with their quotes,
+// like 10 to accept at most 50 bytes under "key", to flag the tiny
+// keys holding huge values, a container counting from its opening to
+// its closing byte.
+// zero value disable the checks
+func WithMaxKeyValueLengthRatio(r float64) Option {
+	return func(verifier *Verify) error {
+		if r == 0 {
+			return nil
+		}
+		if !(r > 0) || math.IsInf(r, 1) {
+			return fmt.Errorf("jtp: max key value length ratio must be"+
+				" positive and finite %g", r)
+		}
+		verifier.MaxKeyValueLengthRatio = r
+		verifier.keyValueRatioEnabled = true
+		return nil
+	}
+}
+
+// ratioHandler checks the length ratio of the object entries,
+// forwarding the events to next if set.
+type ratioHandler struct {
+	forwarder
+	max  float64
+	data []byte
+	// open has the open containers, for an object the span of its
+	// last key and the offset of its container value, if any.
+	open []ratioFrame
+}
+
+type ratioFrame struct {
+	object           bool
+	keyStart, keyEnd int
+	valueStart       int
+}
+
+// check fails if the value span of end-start bytes is too long for
+// the last key of the innermost object.
+func (h *ratioHandler) check(start, end int) error {
+	k := len(h.open) - 1
+	if k < 0 || !h.open[k].object {
+		return nil
+	}
+	f := h.open[k]
+	if float64(end-start) > h.max*float64(f.keyEnd-f.keyStart) {
+		return fmt.Errorf("jtp.keyValueRatioExceeded.Key-[%s]",
+			appendDecodedString(nil, h.data, f.keyStart, f.keyEnd))
+	}
+	return nil
+}
+
+func (h *ratioHandler) begin(c byte, i int) error {
+	if k := len(h.open) - 1; k >= 0 {
+		h.open[k].valueStart = i
+	}
+	h.open = append(h.open, ratioFrame{object: c == '{'})
+	return h.forwarder.begin(c, i)
+}
+
+func (h *ratioHandler) end(c byte, i int) error {
+	h.open = h.open[:len(h.open)-1]
+	if k := len(h.open) - 1; k >= 0 {
+		if err := h.check(h.open[k].valueStart, i+1); err != nil {
+			return err
+		}
+	}
+	return h.forwarder.end(c, i)
+}
+
+func (h *ratioHandler) key(data []byte, startIndex, endIndex int) error {
+	h.data = data
+	f := &h.open[len(h.open)-1]
+	f.keyStart, f.keyEnd = startIndex, endIndex
+	return h.forwarder.key(data, startIndex, endIndex)
+}
+
+func (h *ratioHandler) value(data []byte, startIndex, endIndex int) error {
+	if err := h.check(startIndex, endIndex); err != nil {
+		return err
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
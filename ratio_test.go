@@ -0,0 +1,58 @@
+package gojtp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMaxKeyValueLengthRatio(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxKeyValueLengthRatio(4))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "balanced entries",
+			json: `{"name": "ana", "id": 12345678, "tags": ["a", "b"]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "huge string value",
+			json: `{"a": "` + strings.Repeat("x", 100) + `"}`,
+			err:  fmt.Errorf("jtp.keyValueRatioExceeded.Key-[a]"),
+			ok:   false,
+		},
+		{
+			name: "huge container value",
+			json: `{"ok": 1, "b": [1, 2, 3, 4, 5]}`,
+			err:  fmt.Errorf("jtp.keyValueRatioExceeded.Key-[b]"),
+			ok:   false,
+		},
+		{
+			name: "nested entry",
+			json: `{"outer": {"i": 1234567890123}}`,
+			err:  fmt.Errorf("jtp.keyValueRatioExceeded.Key-[i]"),
+			ok:   false,
+		},
+		{
+			name: "array values",
+			json: `["` + strings.Repeat("x", 100) + `"]`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithMaxKeyValueLengthRatio(-1)); err == nil {
+		t.Errorf("Expected an error for a negative ratio")
+	}
+}
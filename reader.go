@@ -0,0 +1,166 @@
+package gojtp
+
+import (
+	"bufio"
+	"hash"
+	"io"
+)
+
+// readChunkSize is the size of each read from a reader.
+const readChunkSize = 32 << 10
+
+// VerifyReader returns true if the json read from r is valid json
+// and is JSON THREAT Protection Safe.
+//
+// The reading stops as soon as the read size exceeds the
+// MaxDocumentSize, a container the JSONContainerDepth, a byte can't
+// be where it is in a valid JSON, a string exceeds the StringValueLen
+// or a key the ObjectKeyLength, or a token the MaxTokenBytes, so
+// oversized strings are rejected without being read whole, in this
+// case the reported Found is a lower bound.
+//
+// The other checks need the whole json, so the json read is kept in
+// memory until the end of r, bounded by the MaxDocumentSize if
+// enabled.
+func (v Verify) VerifyReader(r io.Reader) (bool, error) {
+	return v.verifyReader(r)
+}
+
+// VerifyReaderHash returns true if the json read from r is valid json
+// and is JSON THREAT Protection Safe, and writes each chunk read from r
+// to h, so a dedup pipeline gets the digest and the verdict from a
+// single read of the body.
+//
+// The reading stops early as VerifyReader, in this case h only
+// covers the part read. The json is kept in memory as in
+// VerifyReader, only the digest is computed without a second copy.
+func (v Verify) VerifyReaderHash(r io.Reader, h hash.Hash) (bool, error) {
+	return v.verifyReader(io.TeeReader(r, h))
+}
+
+// verifyReader reads the json from r in chunks, up to one byte past
+// the MaxDocumentSize if enabled, scanning the string lengths while
+// reading, and verifies it.
+func (v Verify) verifyReader(r io.Reader) (bool, error) {
+	if v.documentSizeEnabled {
+		// one more byte to detect the limit is exceeded
+		r = io.LimitReader(r, int64(v.MaxDocumentSize)+1)
+	}
+	res := v.NewResumable()
+	chunk := make([]byte, readChunkSize)
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			if err := res.Write(chunk[:n]); err != nil {
+				return false, err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return res.Finish()
+}
+
+// VerifyOne reads from r exactly one complete top level object or
+// array and returns the number of bytes consumed, leading whitespace
+// included, and true if it is valid json and is JSON THREAT
+// Protection Safe. The bytes after the value are left in r, so that
+// the caller can read the next value of a stream without framing.
+//
+// r is read one byte at a time, with ReadByte if r is an io.ByteReader
+// like a bufio.Reader. A top level value other than an object or an
+// array fails with ErrBareTopLevelValue, as its end can't be found
+// without reading past it. The string lengths and the MaxDocumentSize
+// are enforced while reading, as in VerifyReader, the
+// JSONContainerDepth once the value is read.
+func (v Verify) VerifyOne(r io.Reader) (consumed int, ok bool, err error) {
+	br, isByteReader := r.(io.ByteReader)
+	if !isByteReader {
+		br = &oneByteReader{r: r}
+	}
+	// the depth is checked once the value is read, so that the
+	// next value of the stream can still be read
+	scanned := v
+	scanned.jsonContainerDepthEnabled = false
+	sc := stringScanner{verifier: &scanned}
+	var buf []byte
+	started := false
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return len(buf), false, err
+		}
+		buf = append(buf, c)
+		if v.documentSizeEnabled && len(buf) > v.MaxDocumentSize {
+			return len(buf), false, newLimitError(documentSize,
+				v.MaxDocumentSize, len(buf), v.MaxDocumentSize)
+		}
+		if !started {
+			switch c {
+			case ' ', '\t', '\n', '\r':
+				continue
+			case '{', '[':
+				started = true
+			case '"', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
+				't', 'f', 'n':
+				return len(buf), false, ErrBareTopLevelValue
+			default:
+				return len(buf), false, firstByteError(c, len(buf)-1)
+			}
+		}
+		if err := sc.scan(buf[len(buf)-1:]); err != nil {
+			return len(buf), false, err
+		}
+		if len(sc.stack) == 0 && !sc.inString {
+			break
+		}
+	}
+	if !started {
+		return len(buf), false, ErrEmptyInput
+	}
+	ok, err = v.VerifyBytes(buf)
+	return len(buf), ok, err
+}
+
+// VerifyScanner verifies each token of sc as a json document,
+// for the record protocols framed by the split function of sc
+// set by the caller, like bufio.ScanLines for newline delimited json.
+// fn is called with the index of each record, starting at 0, and
+// its result, the iteration stops when fn returns false.
+//
+// The returned error is the error of sc, if any.
+func (v Verify) VerifyScanner(sc *bufio.Scanner,
+	fn func(record int, ok bool, err error) bool) error {
+	for record := 0; sc.Scan(); record++ {
+		ok, err := v.VerifyBytes(sc.Bytes())
+		if !fn(record, ok, err) {
+			break
+		}
+	}
+	return sc.Err()
+}
+
+// oneByteReader reads one byte at a time from r.
+type oneByteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (o *oneByteReader) ReadByte() (byte, error) {
+	for {
+		n, err := o.r.Read(o.buf[:])
+		if n == 1 {
+			return o.buf[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,369 @@
+package gojtp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestVerifyReaderHash(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxDocumentSize(4096),
+		WithMaxContainerDepth(7))
+	v := verifier.(Verify)
+	scenarios := []struct {
+		name string
+		json []byte
+		err  error
+		ok   bool
+	}{
+		{name: "valid", json: _getTestJSONBytes(), err: nil, ok: true},
+		{
+			name: "invalid",
+			json: []byte(`{"a": [1, 2}`),
+			err:  ErrInvalidJSON,
+			ok:   false,
+		},
+		{
+			name: "too large",
+			json: bytes.Repeat([]byte(" "), 5000),
+			err:  fmt.Errorf("jtp.maxDocumentSizeReached.Max-[4096]-Allowed.Found-[4097]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			h := sha256.New()
+			r := iotest.HalfReader(bytes.NewReader(tc.json))
+			ok, err := v.VerifyReaderHash(r, h)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			if tc.ok {
+				want := sha256.Sum256(tc.json)
+				if !bytes.Equal(h.Sum(nil), want[:]) {
+					t.Errorf("streamed hash differs from full-buffer hash")
+				}
+			}
+		})
+	}
+}
+
+// repeatReader reads prefix then repeats b up to n bytes then suffix.
+type repeatReader struct {
+	prefix, b, suffix []byte
+	n, read           int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	total := len(r.prefix) + r.n + len(r.suffix)
+	if r.read >= total {
+		return 0, io.EOF
+	}
+	i := 0
+	for ; i < len(p) && r.read < total; i++ {
+		switch {
+		case r.read < len(r.prefix):
+			p[i] = r.prefix[r.read]
+		case r.read < len(r.prefix)+r.n:
+			p[i] = r.b[(r.read-len(r.prefix))%len(r.b)]
+		default:
+			p[i] = r.suffix[r.read-len(r.prefix)-r.n]
+		}
+		r.read++
+	}
+	return i, nil
+}
+
+func TestVerifyReaderDocumentSize(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxDocumentSize(1000))
+	v := verifier.(Verify)
+	sizeErr := fmt.Errorf(
+		"jtp.maxDocumentSizeReached.Max-[1000]-Allowed.Found-[1001]")
+
+	r := &repeatReader{prefix: []byte(`[`), b: []byte("1, "), n: 1 << 62}
+	ok, err := v.VerifyReader(r)
+	checkResult(t, false, ok, sizeErr, err)
+	// the size is a hard read cap, one byte past it is enough
+	if r.read != 1001 {
+		t.Errorf("Expected 1001 bytes read Got %d", r.read)
+	}
+
+	r = &repeatReader{prefix: []byte(`[`), b: []byte("1, "), n: 1 << 62}
+	_, ok, err = v.VerifyOne(r)
+	checkResult(t, false, ok, sizeErr, err)
+	if r.read != 1001 {
+		t.Errorf("Expected 1001 bytes read by VerifyOne Got %d", r.read)
+	}
+}
+
+func TestVerifyReaderStringLength(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(8), WithMaxObjectKeyLength(4))
+	v := verifier.(Verify)
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "within limits",
+			json: `{"ab\"c": ["12345678", "日本語é😀\n"]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "surrogate pairs within limit",
+			json: `["` + strings.Repeat(`\ud83d\ude00`, 8) + `"]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "value too long",
+			json: `{"a": ["123456789"]}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[9]"),
+			ok:   false,
+		},
+		{
+			name: "key too long",
+			json: `[{"k": 1, "abcde": "x"}]`,
+			err:  fmt.Errorf("jtp.maxKeyLengthReached.Max-[4]-Allowed.Found-[5]"),
+			ok:   false,
+		},
+		{
+			name: "escaped value too long",
+			json: `["éééééééé\n"]`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[9]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			r := iotest.OneByteReader(strings.NewReader(tc.json))
+			ok, err := v.VerifyReader(r)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	t.Run("string bomb", func(t *testing.T) {
+		r := &repeatReader{prefix: []byte(`{"a": "`), b: []byte("x"),
+			n: 1 << 30, suffix: []byte(`"}`)}
+		ok, err := v.VerifyReader(r)
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[9]"), err)
+		if r.read > readChunkSize {
+			t.Errorf("Expected early rejection Got %d bytes read", r.read)
+		}
+	})
+}
+
+func TestVerifyReaderTokenBytes(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxTokenBytes(8))
+	v := verifier.(Verify)
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "within limit",
+			json: `{"ab\"c": [-1.5e-10, "\u00e9", false, null]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "long key",
+			json: `{"abcdefg": 1}`,
+			err:  fmt.Errorf("jtp.tokenTooLong.Max-[8]-Allowed.Found-[9]"),
+			ok:   false,
+		},
+		{
+			name: "long escaped value",
+			json: `["\u00e9x"]`,
+			err:  fmt.Errorf("jtp.tokenTooLong.Max-[8]-Allowed.Found-[9]"),
+			ok:   false,
+		},
+		{
+			name: "long number",
+			json: `[1, 123456789]`,
+			err:  fmt.Errorf("jtp.tokenTooLong.Max-[8]-Allowed.Found-[9]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := v.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			ok, err = v.VerifyReader(strings.NewReader(tc.json))
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	for name, r := range map[string]*repeatReader{
+		"unterminated string": {prefix: []byte(`{"a": "`), b: []byte("x"),
+			n: 1 << 62},
+		"unterminated number": {prefix: []byte(`[1`), b: []byte("0"),
+			n: 1 << 62},
+	} {
+		r := r
+		t.Run(name, func(t *testing.T) {
+			ok, err := v.VerifyReader(r)
+			checkResult(t, false, ok, fmt.Errorf(
+				"jtp.tokenTooLong.Max-[8]-Allowed.Found-[9]"), err)
+			if r.read > readChunkSize {
+				t.Errorf("Expected early rejection Got %d bytes read", r.read)
+			}
+		})
+	}
+}
+
+func TestVerifyOne(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(3))
+	v := verifier.(Verify)
+	stream := "{\"a\": \"}\"}\n [1, [2, \"]\"]] {\"b\": [[[1]]]}\n"
+	for _, r := range []io.Reader{
+		strings.NewReader(stream),
+		iotest.HalfReader(strings.NewReader(stream)),
+	} {
+		scenarios := []struct {
+			consumed int
+			err      error
+			ok       bool
+		}{
+			{consumed: 10, err: nil, ok: true},
+			{consumed: 15, err: nil, ok: true},
+			{
+				consumed: 15,
+				err:      fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[3]-Allowed.Found-[4]"),
+				ok:       false,
+			},
+			{consumed: 1, err: ErrEmptyInput, ok: false},
+		}
+		for _, tc := range scenarios {
+			consumed, ok, err := v.VerifyOne(r)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			if consumed != tc.consumed {
+				t.Errorf("Expected %d bytes consumed Got %d", tc.consumed,
+					consumed)
+			}
+		}
+	}
+
+	_, ok, err := v.VerifyOne(strings.NewReader(`42 {}`))
+	checkResult(t, false, ok, ErrBareTopLevelValue, err)
+}
+
+func TestVerifierVerifyReader(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(5))
+	pool, _ := NewPool(WithMaxStringLength(5))
+	depth, _ := New(WithMaxContainerDepth(2))
+	verifiers := map[string]Verifier{
+		"verify":  verifier,
+		"pool":    pool,
+		"combine": Combine(verifier, depth),
+		"timeout": WithTimeout(verifier, time.Minute),
+	}
+	stringErr := fmt.Errorf("jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[6]")
+	for name, v := range verifiers {
+		t.Run(name, func(t *testing.T) {
+			ok, err := v.VerifyReader(strings.NewReader(`{"a": ["abc"]}`))
+			checkResult(t, true, ok, nil, err)
+			ok, err = v.VerifyReader(strings.NewReader(`["abcdef"]`))
+			checkResult(t, false, ok, stringErr, err)
+		})
+	}
+}
+
+// scanLengthPrefixed is a bufio.SplitFunc for records framed as
+// their decimal length, a colon and the record.
+func scanLengthPrefixed(data []byte, atEOF bool) (int, []byte, error) {
+	colon := bytes.IndexByte(data, ':')
+	if colon < 0 {
+		if atEOF && len(data) > 0 {
+			return 0, nil, io.ErrUnexpectedEOF
+		}
+		return 0, nil, nil
+	}
+	n, err := strconv.Atoi(string(data[:colon]))
+	if err != nil {
+		return 0, nil, err
+	}
+	end := colon + 1 + n
+	if end > len(data) {
+		if atEOF {
+			return 0, nil, io.ErrUnexpectedEOF
+		}
+		return 0, nil, nil
+	}
+	return end, data[colon+1 : end], nil
+}
+
+func TestVerifyScanner(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(2))
+	v := verifier.(Verify)
+	countErr := fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]")
+	stream := `8:{"a": 1}9:[1, 2, 3]4:[1, 8:{"b": 2}`
+	expected := []struct {
+		err error
+		ok  bool
+	}{
+		{err: nil, ok: true},
+		{err: countErr, ok: false},
+		{err: ErrInvalidJSON, ok: false},
+		{err: nil, ok: true},
+	}
+
+	t.Run("all records", func(t *testing.T) {
+		sc := bufio.NewScanner(strings.NewReader(stream))
+		sc.Split(scanLengthPrefixed)
+		records := 0
+		err := v.VerifyScanner(sc, func(record int, ok bool, err error) bool {
+			if record != records {
+				t.Errorf("Expected record %d Got %d", records, record)
+			}
+			checkResult(t, expected[record].ok, ok, expected[record].err, err)
+			records++
+			return true
+		})
+		if err != nil || records != len(expected) {
+			t.Errorf("Expected %d records and nil error Got %d %v",
+				len(expected), records, err)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		sc := bufio.NewScanner(strings.NewReader(stream))
+		sc.Split(scanLengthPrefixed)
+		records := 0
+		_ = v.VerifyScanner(sc, func(record int, ok bool, err error) bool {
+			records++
+			return ok
+		})
+		if records != 2 {
+			t.Errorf("Expected to stop after 2 records Got %d", records)
+		}
+	})
+
+	t.Run("framing error", func(t *testing.T) {
+		sc := bufio.NewScanner(strings.NewReader(`8:{"a": 1}9:[1`))
+		sc.Split(scanLengthPrefixed)
+		err := v.VerifyScanner(sc, func(record int, ok bool, err error) bool {
+			return true
+		})
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("Expected io.ErrUnexpectedEOF Got %v", err)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package gojtp
+
+import "bytes"
+
+// Resumable verifies a json delivered in parts across separate
+// calls, like the chunks of a resumable upload, carrying the state
+// between them. It must be created with the NewResumable method.
+type Resumable struct {
+	verifier Verify
+	sc       stringScanner
+	buf      bytes.Buffer
+	// err is the first violation found by Write.
+	err error
+}
+
+// NewResumable returns a Resumable verifying with the limits of v.
+func (v Verify) NewResumable() *Resumable {
+	r := &Resumable{verifier: v}
+	r.sc = stringScanner{verifier: &r.verifier}
+	return r
+}
+
+// Write adds the next part of the json and returns an error as soon
+// as a violation is provable from the parts written so far: the
+// MaxDocumentSize or the JSONContainerDepth is exceeded, a byte can't
+// be where it is in a valid JSON, or a string exceeds the
+// StringValueLen or a key the ObjectKeyLength, in this case the
+// reported Found is a lower bound. Once it failed, Write and Finish
+// return the same error.
+func (r *Resumable) Write(p []byte) error {
+	if r.err != nil {
+		return r.err
+	}
+	if n := r.buf.Len() + len(p); r.verifier.documentSizeEnabled &&
+		n > r.verifier.MaxDocumentSize {
+		r.err = newLimitError(documentSize, r.verifier.MaxDocumentSize,
+			n, r.verifier.MaxDocumentSize)
+		return r.err
+	}
+	if r.err = r.sc.scan(p); r.err != nil {
+		return r.err
+	}
+	r.buf.Write(p)
+	return nil
+}
+
+// Finish returns true if the json written is valid json and is JSON
+// THREAT Protection Safe, all the checks are applied on the whole json.
+func (r *Resumable) Finish() (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+	return r.verifier.VerifyBytes(r.buf.Bytes())
+}
@@ -0,0 +1,134 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestResumable(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(8), WithMaxDocumentSize(64),
+		WithMaxArrayElementCount(3))
+	scenarios := []struct {
+		name     string
+		parts    []string
+		writeErr error
+		// failed is the index of the part whose Write fails
+		failed int
+		err    error
+		ok     bool
+	}{
+		{
+			name:   "valid",
+			parts:  []string{`{"a": [1, `, `"ab`, `cd"], "b":`, ` true}`},
+			failed: -1,
+			err:    nil,
+			ok:     true,
+		},
+		{
+			name:     "long string across parts",
+			parts:    []string{`{"a": "abcd`, `efghij`, `kl"}`},
+			writeErr: fmt.Errorf("jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[9]"),
+			failed:   1,
+			err:      fmt.Errorf("jtp.maxStringValueLengthReached.Max-[8]-Allowed.Found-[9]"),
+			ok:       false,
+		},
+		{
+			name:     "document size",
+			parts:    []string{`[` + fmt.Sprintf("%40d", 1), `, ` + fmt.Sprintf("%40d", 2) + `]`},
+			writeErr: fmt.Errorf("jtp.maxDocumentSizeReached.Max-[64]-Allowed.Found-[84]"),
+			failed:   1,
+			err:      fmt.Errorf("jtp.maxDocumentSizeReached.Max-[64]-Allowed.Found-[84]"),
+			ok:       false,
+		},
+		{
+			name:   "only provable at the end",
+			parts:  []string{`[1, 2, `, `3, 4]`},
+			failed: -1,
+			err:    fmt.Errorf("jtp.maxArrayElementCountReached.Max-[3]-Allowed.Found-[4]"),
+			ok:     false,
+		},
+		{
+			name:   "truncated",
+			parts:  []string{`{"a": `},
+			failed: -1,
+			err:    ErrInvalidJSON,
+			ok:     false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			r := verifier.(Verify).NewResumable()
+			for i, part := range tc.parts {
+				err := r.Write([]byte(part))
+				if i < tc.failed || tc.failed < 0 {
+					checkResult(t, false, false, nil, err)
+				} else {
+					checkResult(t, false, false, tc.writeErr, err)
+				}
+			}
+			ok, err := r.Finish()
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
+
+func TestResumableStructure(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(2))
+	depthErr := fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[2]-Allowed.Found-[3]")
+	scenarios := []struct {
+		name     string
+		parts    []string
+		writeErr error
+		// failed is the index of the part whose Write fails
+		failed int
+	}{
+		{
+			name:     "depth mid-stream",
+			parts:    []string{`{"a": [`, `[[[[[`, `1]]]]]]}`},
+			writeErr: depthErr,
+			failed:   1,
+		},
+		{
+			name:     "garbage",
+			parts:    []string{`[1, `, `x garbage`, `]`},
+			writeErr: ErrInvalidJSON,
+			failed:   1,
+		},
+		{
+			name:     "mismatched closing",
+			parts:    []string{`{"a": [1}`},
+			writeErr: ErrInvalidJSON,
+			failed:   0,
+		},
+		{
+			name:     "after the top level",
+			parts:    []string{`[1]`, ` [2]`},
+			writeErr: ErrInvalidJSON,
+			failed:   1,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			r := verifier.(Verify).NewResumable()
+			for i, part := range tc.parts {
+				err := r.Write([]byte(part))
+				if i < tc.failed {
+					checkResult(t, false, false, nil, err)
+				} else {
+					checkResult(t, false, false, tc.writeErr, err)
+				}
+			}
+			ok, err := r.Finish()
+			checkResult(t, false, ok, tc.writeErr, err)
+		})
+	}
+
+	rr := &repeatReader{prefix: []byte(`[`), b: []byte("["), n: 50 << 20}
+	ok, err := verifier.VerifyReader(rr)
+	checkResult(t, false, ok, depthErr, err)
+	if rr.read > readChunkSize {
+		t.Errorf("Expected early rejection Got %d bytes read", rr.read)
+	}
+}
@@ -0,0 +1,78 @@
+package gojtp
+
+import "bytes"
+
+// Sanitizer returns the sanitized form of a decoded string value,
+// or the value itself to keep it unchanged. value can be modified in
+// place but must not be retained after the call.
+type Sanitizer func(value []byte) []byte
+
+// sanitizeHandler rewrites the string values changed by sanitize.
+type sanitizeHandler struct {
+	sanitize Sanitizer
+	out      []byte
+	// last is the offset in the input copied to out so far.
+	last int
+	buf  []byte
+	// orig is the untouched copy of buf, as the sanitizer may
+	// modify it in place.
+	orig []byte
+}
+
+func (h *sanitizeHandler) begin(c byte, i int) error {
+	return nil
+}
+
+func (h *sanitizeHandler) end(c byte, i int) error {
+	return nil
+}
+
+func (h *sanitizeHandler) key(data []byte, startIndex, endIndex int) error {
+	return nil
+}
+
+func (h *sanitizeHandler) value(data []byte, startIndex, endIndex int) error {
+	if data[startIndex] != '"' {
+		return nil
+	}
+	h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+	h.orig = append(h.orig[:0], h.buf...)
+	cleaned := h.sanitize(h.buf)
+	if bytes.Equal(cleaned, h.orig) {
+		return nil
+	}
+	h.out = append(h.out, data[h.last:startIndex]...)
+	h.out = appendCanonicalString(h.out, cleaned)
+	h.last = endIndex
+	return nil
+}
+
+// VerifyAndSanitize passes each string value of the json to sanitizer
+// and returns a copy of the json with the changed values re-encoded,
+// the rest of the json is copied byte for byte, and true if the
+// cleaned copy is valid json and is JSON THREAT Protection Safe,
+// e.g. to replace the characters a charset disallows instead of
+// rejecting the whole document. The keys are never sanitized.
+//
+// Sanitizing is a weaker defense than rejecting: a sanitizer
+// removing a pattern may create it, like "<scr<scriptipt" becoming
+// "<script" when "<script" is removed once, so it must apply its
+// rule until nothing changes. The cleaned copy is verified again
+// with all the limits of v, a value the sanitizer misses still fails,
+// but the checks of v only cover what they express: the cleaned
+// document is not safe to embed in HTML or SQL because of it.
+//
+// The structural limits of v, see VerifyStructure, apply before any
+// value is passed to sanitizer, a json breaking them or malformed
+// fails with a nil copy.
+func (v Verify) VerifyAndSanitize(json []byte,
+	sanitizer Sanitizer) ([]byte, bool, error) {
+	h := &sanitizeHandler{sanitize: sanitizer}
+	s := state{verifier: v.structure(), h: h}
+	if ok, err := s.verify(json); !ok || err != nil {
+		return nil, ok, err
+	}
+	cleaned := append(h.out, json[h.last:]...)
+	ok, err := v.VerifyBytes(cleaned)
+	return cleaned, ok, err
+}
@@ -0,0 +1,104 @@
+package gojtp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVerifyAndSanitize(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(20))
+	v := verifier.(Verify)
+	stripScript := func(value []byte) []byte {
+		for bytes.Contains(value, []byte("<script")) {
+			value = bytes.ReplaceAll(value, []byte("<script"), nil)
+		}
+		return value
+	}
+	scenarios := []struct {
+		name    string
+		json    string
+		cleaned string
+		err     error
+		ok      bool
+	}{
+		{
+			name:    "unchanged",
+			json:    `{"a": ["x", 1, null], "b": "é"}`,
+			cleaned: `{"a": ["x", 1, null], "b": "é"}`,
+			err:     nil,
+			ok:      true,
+		},
+		{
+			name:    "stripped",
+			json:    `{"<script": "hi <script>", "b": ["<scr<scriptipt\"x"]}`,
+			cleaned: `{"<script": "hi >", "b": ["\"x"]}`,
+			err:     nil,
+			ok:      true,
+		},
+		{
+			name:    "still too long",
+			json:    `["<script>aaaaaaaaaaaaaaaaaaaaa"]`,
+			cleaned: `[">aaaaaaaaaaaaaaaaaaaaa"]`,
+			err:     fmt.Errorf("jtp.maxStringValueLengthReached.Max-[20]-Allowed.Found-[22]"),
+			ok:      false,
+		},
+		{
+			name: "malformed",
+			json: `["<script>", ]`,
+			err:  &MalformedError{Reason: trailingComma, Offset: 11},
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			cleaned, ok, err := v.VerifyAndSanitize([]byte(tc.json),
+				stripScript)
+			checkResult(t, tc.ok, ok, tc.err, err)
+			if string(cleaned) != tc.cleaned {
+				t.Errorf("Expected %s Got %s", tc.cleaned, cleaned)
+			}
+		})
+	}
+}
+
+func TestVerifyAndSanitizeInPlace(t *testing.T) {
+	t.Parallel()
+	v := Verify{}
+	underscore := func(value []byte) []byte {
+		for i, c := range value {
+			if c == '<' {
+				value[i] = '_'
+			}
+		}
+		return value
+	}
+	cleaned, ok, err := v.VerifyAndSanitize([]byte(`["<b>", "c"]`),
+		underscore)
+	checkResult(t, true, ok, nil, err)
+	if exp := `["_b>", "c"]`; string(cleaned) != exp {
+		t.Errorf("Expected %s Got %s", exp, cleaned)
+	}
+}
+
+func TestVerifyAndSanitizeStructuralLimits(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxDocumentSize(10))
+	v := verifier.(Verify)
+	calls := 0
+	count := func(value []byte) []byte {
+		calls++
+		return value
+	}
+	json := "[" + strings.Repeat(`"a",`, 1000) + `"a"]`
+	cleaned, ok, err := v.VerifyAndSanitize([]byte(json), count)
+	checkResult(t, false, ok,
+		fmt.Errorf("jtp.maxDocumentSizeReached.Max-[10]-Allowed.Found-[%d]",
+			len(json)), err)
+	if cleaned != nil || calls != 0 {
+		t.Errorf("Expected no copy and no call Got %s and %d calls",
+			cleaned, calls)
+	}
+}
@@ -0,0 +1,116 @@
+package gojtp
+
+import "fmt"
+
+// Type is the type of a JSON value.
+type Type int
+
+// JSON value types.
+const (
+	TypeObject Type = iota + 1
+	TypeArray
+	TypeString
+	TypeNumber
+	TypeBoolean
+	TypeNull
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeObject:
+		return "object"
+	case TypeArray:
+		return "array"
+	case TypeString:
+		return "string"
+	case TypeNumber:
+		return "number"
+	case TypeBoolean:
+		return "boolean"
+	case TypeNull:
+		return "null"
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+// valueType returns the type of the value starting with the byte c.
+func valueType(c byte) Type {
+	switch c {
+	case '{':
+		return TypeObject
+	case '[':
+		return TypeArray
+	case '"':
+		return TypeString
+	case 't', 'f':
+		return TypeBoolean
+	case 'n':
+		return TypeNull
+	}
+	return TypeNumber
+}
+
+// WithTypeSchema Option
+// Specifies the expected type of the values at the given RFC 6901
+// JSON Pointers, where * matches any array index, e.g.
+//
+//	WithTypeSchema(map[string]Type{
+//		"/targets":                  TypeArray,
+//		"/targets/*/req_per_second": TypeNumber,
+//	})
+//
+// A pointer with a concrete index takes precedence over * for the
+// same value. Absent values are not checked.
+func WithTypeSchema(schema map[string]Type) Option {
+	return func(verifier *Verify) error {
+		for pointer, t := range schema {
+			if t < TypeObject || t > TypeNull {
+				return fmt.Errorf("jtp: unknown type %d for %q",
+					int(t), pointer)
+			}
+		}
+		verifier.typeSchema = schema
+		return nil
+	}
+}
+
+// schemaHandler checks the type of the values against a schema,
+// forwarding the events to next if set.
+type schemaHandler struct {
+	pathForwarder
+	schema map[string]Type
+	buf    []byte
+}
+
+func (h *schemaHandler) check(c byte) error {
+	h.buf = h.appendPointer(h.buf[:0])
+	expected, ok := h.schema[string(h.buf)]
+	if !ok {
+		h.buf = h.appendTemplate(h.buf[:0], true)
+		if expected, ok = h.schema[string(h.buf)]; !ok {
+			return nil
+		}
+	}
+	if got := valueType(c); got != expected {
+		return fmt.Errorf("jtp.typeMismatch.Path-[%s].Expected-[%s]."+
+			"Got-[%s]", h.appendPointer(nil), expected, got)
+	}
+	return nil
+}
+
+func (h *schemaHandler) begin(c byte, i int) error {
+	h.pathTracker.next()
+	if err := h.check(c); err != nil {
+		return err
+	}
+	h.push(c)
+	return h.forwarder.begin(c, i)
+}
+
+func (h *schemaHandler) value(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.next()
+	if err := h.check(data[startIndex]); err != nil {
+		return err
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
@@ -0,0 +1,75 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTypeSchema(t *testing.T) {
+	t.Parallel()
+	verifier, err := New(WithTypeSchema(map[string]Type{
+		"":                          TypeObject,
+		"/targets":                  TypeArray,
+		"/targets/*/req_per_second": TypeNumber,
+		"/targets/0/name":           TypeString,
+		"/targets/*/name":           TypeNull,
+		"/enabled":                  TypeBoolean,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "matching",
+			json: `{"targets": [{"name": "a", "req_per_second": 10},
+				{"name": null, "req_per_second": 2.5}], "enabled": true}`,
+			err: nil,
+			ok:  true,
+		},
+		{
+			name: "absent values",
+			json: `{"other": "x"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "container mismatch",
+			json: `{"targets": {"a": 1}}`,
+			err:  fmt.Errorf("jtp.typeMismatch.Path-[/targets].Expected-[array].Got-[object]"),
+			ok:   false,
+		},
+		{
+			name: "wildcard mismatch",
+			json: `{"targets": [{"req_per_second": 1}, {"req_per_second": "10"}]}`,
+			err:  fmt.Errorf("jtp.typeMismatch.Path-[/targets/1/req_per_second].Expected-[number].Got-[string]"),
+			ok:   false,
+		},
+		{
+			name: "concrete index wins",
+			json: `{"targets": [{"name": "a"}, {"name": "b"}]}`,
+			err:  fmt.Errorf("jtp.typeMismatch.Path-[/targets/1/name].Expected-[null].Got-[string]"),
+			ok:   false,
+		},
+		{
+			name: "root mismatch",
+			json: `[1]`,
+			err:  fmt.Errorf("jtp.typeMismatch.Path-[].Expected-[object].Got-[array]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	if _, err := New(WithTypeSchema(map[string]Type{"/a": 0})); err == nil {
+		t.Errorf("Expected an error for an unknown type Got - nil")
+	}
+}
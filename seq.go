@@ -0,0 +1,69 @@
+package gojtp
+
+import (
+	"bytes"
+	"errors"
+)
+
+// recordSeparator starts each element of a JSON text sequence.
+const recordSeparator = 0x1E
+
+// ErrTruncatedSeqRecord denotes a JSON text sequence element whose
+// top level number, true, false or null is not followed by whitespace,
+// so it may have been truncated.
+var ErrTruncatedSeqRecord = errors.New("jtp.truncatedSeqRecord")
+
+// VerifySeq verifies each element of the RFC 7464 JSON text sequence
+// data, the application/json-seq media type where each JSON text is
+// prefixed by the 0x1E record separator and usually ends with a line
+// feed. fn is called with the index of each element, starting at 0,
+// and its result, a malformed element doesn't stop the iteration, it
+// stops when fn returns false.
+//
+// Consecutive separators don't denote empty elements and are skipped.
+// As the RFC requires, an element with a top level number, true,
+// false or null not followed by whitespace fails with
+// ErrTruncatedSeqRecord.
+// https://tools.ietf.org/html/rfc7464
+func (v Verify) VerifySeq(data []byte, fn func(index int, ok bool,
+	err error) bool) {
+	index := 0
+	for len(data) > 0 {
+		var record []byte
+		record, data = splitRecord(data)
+		if len(record) == 0 {
+			continue
+		}
+		ok, err := v.VerifyBytes(record)
+		if ok && isTruncatedRecord(record) {
+			ok, err = false, ErrTruncatedSeqRecord
+		}
+		if !fn(index, ok, err) {
+			return
+		}
+		index++
+	}
+}
+
+// splitRecord returns the first record of data, without its leading
+// separator, and the rest of data from the next separator.
+func splitRecord(data []byte) (record, rest []byte) {
+	if data[0] == recordSeparator {
+		data = data[1:]
+	}
+	if i := bytes.IndexByte(data, recordSeparator); i >= 0 {
+		return data[:i], data[i:]
+	}
+	return data, nil
+}
+
+// isTruncatedRecord reports if the valid json record is a top level
+// scalar, other than a string, not followed by whitespace.
+func isTruncatedRecord(record []byte) bool {
+	switch record[skipSpace(record, 0)] {
+	case '{', '[', '"':
+		return false
+	}
+	last := record[len(record)-1]
+	return last != ' ' && last != '\t' && last != '\n' && last != '\r'
+}
@@ -0,0 +1,64 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVerifySeq(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(2))
+	type result struct {
+		ok  bool
+		err string
+	}
+	scenarios := []struct {
+		name    string
+		seq     string
+		results []result
+	}{
+		{
+			name: "valid",
+			seq:  "\x1e{\"a\": 1}\n\x1e[1, 2]\n\x1e\x1e\"s\"\n\x1e42\n",
+			results: []result{{true, "<nil>"}, {true, "<nil>"},
+				{true, "<nil>"}, {true, "<nil>"}},
+		},
+		{
+			name: "corrupt record",
+			seq:  "\x1e{\"a\": 1}\n\x1e{\"a\": \n\x1e[1, 2, 3]\n\x1etrue\n",
+			results: []result{{true, "<nil>"}, {false, "jtp.MalformedJSON"},
+				{false, "jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"},
+				{true, "<nil>"}},
+		},
+		{
+			name:    "truncated number",
+			seq:     "\x1e[1]\n\x1e123",
+			results: []result{{true, "<nil>"}, {false, "jtp.truncatedSeqRecord"}},
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []result
+			verifier.(Verify).VerifySeq([]byte(tc.seq),
+				func(index int, ok bool, err error) bool {
+					if index != len(got) {
+						t.Errorf("Expected index %d Got %d", len(got), index)
+					}
+					got = append(got, result{ok, fmt.Sprint(err)})
+					return true
+				})
+			if fmt.Sprint(got) != fmt.Sprint(tc.results) {
+				t.Errorf("Expected %v Got %v", tc.results, got)
+			}
+		})
+	}
+	calls := 0
+	verifier.(Verify).VerifySeq([]byte("\x1e{\n\x1e[]\n"),
+		func(index int, ok bool, err error) bool {
+			calls++
+			return false
+		})
+	if calls != 1 {
+		t.Errorf("Expected the iteration to stop Got %d calls", calls)
+	}
+}
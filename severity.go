@@ -0,0 +1,64 @@
+package gojtp
+
+// Severity of a Violation.
+type Severity int
+
+const (
+	// SeverityError fails the validation.
+	SeverityError Severity = iota
+	// SeverityWarning is only reported.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Violation is a check failed by a JSON, with its severity.
+type Violation struct {
+	Err      error
+	Severity Severity
+}
+
+// AsWarning returns an Option applying o as a warning: the checks it
+// configures don't fail VerifyBytes and their violations are only
+// reported by VerifyBytesAll, e.g.
+//
+//	New(WithMaxStringLength(50).AsWarning(), WithMaxContainerDepth(7))
+func (o Option) AsWarning() Option {
+	return func(verifier *Verify) error {
+		var w Verify
+		if err := o(&w); err != nil {
+			return err
+		}
+		verifier.warnings = append(verifier.warnings, w)
+		return nil
+	}
+}
+
+// VerifyBytesAll returns true if the json passes VerifyBytes, and the
+// violations tagged with their severity: the VerifyBytes error if any
+// and the first violation of each warning Option, see AsWarning.
+//
+// Each warning Option takes its own pass over a valid json.
+func (v Verify) VerifyBytesAll(json []byte) (bool, []Violation) {
+	var violations []Violation
+	ok, err := v.VerifyBytes(json)
+	if err != nil {
+		violations = append(violations, Violation{Err: err,
+			Severity: SeverityError})
+	}
+	if len(v.warnings) == 0 || !IsValid(json) {
+		return ok, violations
+	}
+	for _, w := range v.warnings {
+		if _, err := w.VerifyBytes(json); err != nil {
+			violations = append(violations, Violation{Err: err,
+				Severity: SeverityWarning})
+		}
+	}
+	return ok, violations
+}
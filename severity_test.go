@@ -0,0 +1,76 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAsWarning(t *testing.T) {
+	t.Parallel()
+	verifier, err := New(WithMaxStringLength(5).AsWarning(),
+		WithMaxContainerDepth(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := verifier.(Verify)
+	warning := Violation{
+		Err:      fmt.Errorf("jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[6]"),
+		Severity: SeverityWarning,
+	}
+	depth := Violation{
+		Err:      fmt.Errorf("jtp.maxContainerDepthReached.Type-[array].Max-[2]-Allowed.Found-[3]"),
+		Severity: SeverityError,
+	}
+	scenarios := []struct {
+		name       string
+		json       string
+		ok         bool
+		violations []Violation
+	}{
+		{name: "clean", json: `["abc"]`, ok: true},
+		{
+			name:       "warning only",
+			json:       `["abcdef"]`,
+			ok:         true,
+			violations: []Violation{warning},
+		},
+		{
+			name:       "error and warning",
+			json:       `["abcdef", [[1]]]`,
+			ok:         false,
+			violations: []Violation{depth, warning},
+		},
+		{
+			name: "malformed",
+			json: `["abcdef"`,
+			ok:   false,
+			violations: []Violation{
+				{Err: ErrInvalidJSON, Severity: SeverityError},
+			},
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			if ok, _ := v.VerifyString(tc.json); ok != tc.ok {
+				t.Errorf("Expected validation %v Got %v", tc.ok, ok)
+			}
+			ok, violations := v.VerifyBytesAll([]byte(tc.json))
+			if ok != tc.ok {
+				t.Errorf("Expected validation %v Got %v", tc.ok, ok)
+			}
+			if len(violations) != len(tc.violations) {
+				t.Fatalf("Expected %v Got %v", tc.violations, violations)
+			}
+			for i, exp := range tc.violations {
+				if violations[i].Severity != exp.Severity ||
+					violations[i].Err.Error() != exp.Err.Error() {
+					t.Errorf("Expected %v Got %v", exp, violations[i])
+				}
+			}
+		})
+	}
+
+	if _, err := New(WithMaxStringLength(-1).AsWarning()); err == nil {
+		t.Errorf("Expected an error for a negative option Got - nil")
+	}
+}
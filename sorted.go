@@ -0,0 +1,166 @@
+package gojtp
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Order is the order required of the values of an array.
+type Order int
+
+// Array orders.
+const (
+	// Ascending allows equal neighbors, like [1, 2, 2, 3].
+	Ascending Order = iota + 1
+	// StrictlyAscending rejects equal neighbors.
+	StrictlyAscending
+	// Descending allows equal neighbors, like [3, 2, 2, 1].
+	Descending
+	// StrictlyDescending rejects equal neighbors.
+	StrictlyDescending
+)
+
+// allows reports if a value comparing as cmp to the previous one
+// keeps the order.
+func (o Order) allows(cmp int) bool {
+	switch o {
+	case Ascending:
+		return cmp >= 0
+	case StrictlyAscending:
+		return cmp > 0
+	case Descending:
+		return cmp <= 0
+	}
+	return cmp < 0
+}
+
+// sortRule requires the arrays under key to be in order.
+type sortRule struct {
+	key   string
+	order Order
+}
+
+// WithSortedArrayForKey Option
+// Specifies that every array under key, like the ids of a batch, must
+// be in order, with only numbers compared by value or only strings
+// compared byte-wise once decoded. A value of another type or
+// following a value of another type breaks the order. The numbers are
+// compared as float64, so the integers beyond 2^53 may compare equal.
+// It can be passed multiple times, the first matching key applies.
+func WithSortedArrayForKey(key string, order Order) Option {
+	return func(verifier *Verify) error {
+		if order < Ascending || order > StrictlyDescending {
+			return fmt.Errorf("jtp: unknown array order %d", order)
+		}
+		verifier.sortedArrays = append(verifier.sortedArrays,
+			sortRule{key: key, order: order})
+		return nil
+	}
+}
+
+// sortedHandler checks the order of the arrays under the keys of
+// the rules, forwarding the events to next if set.
+type sortedHandler struct {
+	forwarder
+	rules []sortRule
+	// frames has for each open container the index of its rule,
+	// -1 for none.
+	frames []int
+	// pending is the rule of the last key, -1 for none.
+	pending int
+	// seen is set once the innermost array with a rule has a value,
+	// prev is the last one decoded if str, number its value if not.
+	seen   bool
+	str    bool
+	prev   []byte
+	number float64
+	buf    []byte
+}
+
+// rule returns the rule of the innermost container, -1 for none.
+func (h *sortedHandler) rule() int {
+	if len(h.frames) == 0 {
+		return -1
+	}
+	return h.frames[len(h.frames)-1]
+}
+
+func (h *sortedHandler) notSorted(rule int) error {
+	return fmt.Errorf("jtp.arrayNotSorted.Key-[%s]", h.rules[rule].key)
+}
+
+// check fails if the value span breaks the order of its array.
+func (h *sortedHandler) check(data []byte, startIndex, endIndex int) error {
+	rule := h.rule()
+	if rule < 0 {
+		return nil
+	}
+	c := data[startIndex]
+	if c != '"' && c != '-' && (c < '0' || c > '9') {
+		return h.notSorted(rule)
+	}
+	str := c == '"'
+	if h.seen && h.str != str {
+		return h.notSorted(rule)
+	}
+	var cmp int
+	if str {
+		h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+		cmp = bytes.Compare(h.buf, h.prev)
+		h.prev, h.buf = h.buf, h.prev
+	} else {
+		n, _ := strconv.ParseFloat(string(data[startIndex:endIndex]), 64)
+		switch {
+		case n > h.number:
+			cmp = 1
+		case n < h.number:
+			cmp = -1
+		}
+		h.number = n
+	}
+	first := !h.seen
+	h.seen, h.str = true, str
+	if !first && !h.rules[rule].order.allows(cmp) {
+		return h.notSorted(rule)
+	}
+	return nil
+}
+
+func (h *sortedHandler) begin(c byte, i int) error {
+	if rule := h.rule(); rule >= 0 {
+		return h.notSorted(rule)
+	}
+	rule := -1
+	if c == '[' {
+		rule = h.pending
+		h.seen = false
+	}
+	h.frames = append(h.frames, rule)
+	h.pending = -1
+	return h.forwarder.begin(c, i)
+}
+
+func (h *sortedHandler) end(c byte, i int) error {
+	h.frames = h.frames[:len(h.frames)-1]
+	return h.forwarder.end(c, i)
+}
+
+func (h *sortedHandler) key(data []byte, startIndex, endIndex int) error {
+	h.pending = -1
+	for j, rule := range h.rules {
+		if stringEquals(data, startIndex, endIndex, rule.key) {
+			h.pending = j
+			break
+		}
+	}
+	return h.forwarder.key(data, startIndex, endIndex)
+}
+
+func (h *sortedHandler) value(data []byte, startIndex, endIndex int) error {
+	h.pending = -1
+	if err := h.check(data, startIndex, endIndex); err != nil {
+		return err
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
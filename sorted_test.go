@@ -0,0 +1,85 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSortedArrayForKey(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithSortedArrayForKey("ids", StrictlyAscending),
+		WithSortedArrayForKey("names", Descending))
+	notSorted := func(key string) error {
+		return fmt.Errorf("jtp.arrayNotSorted.Key-[%s]", key)
+	}
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "sorted ids",
+			json: `{"ids": [-2, 1, 1.5, 10, 1e2], "other": [3, 2]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "out of order",
+			json: `{"ids": [1, 2, 10, 9]}`,
+			err:  notSorted("ids"),
+			ok:   false,
+		},
+		{
+			name: "duplicate in strict order",
+			json: `{"ids": [1, 2, 2.0]}`,
+			err:  notSorted("ids"),
+			ok:   false,
+		},
+		{
+			name: "descending strings",
+			json: `{"names": ["zoe", "bé", "bob", "bob", ""]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "ascending strings",
+			json: `{"names": ["ana", "bob"]}`,
+			err:  notSorted("names"),
+			ok:   false,
+		},
+		{
+			name: "mixed types",
+			json: `{"ids": [1, "2"]}`,
+			err:  notSorted("ids"),
+			ok:   false,
+		},
+		{
+			name: "nested array",
+			json: `{"ids": [1, [2]]}`,
+			err:  notSorted("ids"),
+			ok:   false,
+		},
+		{
+			name: "nested sorted arrays",
+			json: `[{"ids": [1, 2]}, {"ids": [1, 3]}, {"ids": []}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "not an array",
+			json: `{"ids": {"a": 2, "b": 1}, "names": "x"}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	if _, err := New(WithSortedArrayForKey("ids", Order(0))); err == nil {
+		t.Errorf("Expected an error for an unknown order")
+	}
+}
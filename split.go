@@ -0,0 +1,37 @@
+package gojtp
+
+// Range delimits a JSON value in a buffer, as data[Start:End].
+type Range struct {
+	Start int
+	End   int
+}
+
+// SplitDocuments verifies each value of data, a concatenation of
+// JSON values separated by optional whitespace, as VerifyBytes does
+// and returns the Range of each one, without the whitespace between
+// them, e.g. to forward each document of a stream separately.
+//
+// It stops at the first invalid or unsafe value, returning the ranges
+// before it and its VerifyBytes error, whose offsets are relative to
+// the start of the value. An empty or whitespace only data fails with
+// ErrEmptyInput.
+func (v Verify) SplitDocuments(data []byte) ([]Range, error) {
+	var ranges []Range
+	for i := skipSpace(data, 0); i < len(data); i = skipSpace(data, i) {
+		// delimit the value structurally, then apply the policy to it
+		s := state{verifier: Verify{}}
+		end, ok, _ := validany(data, i, &s)
+		if !ok {
+			end = len(data)
+		}
+		if ok, err := v.VerifyBytes(data[i:end]); !ok {
+			return ranges, err
+		}
+		ranges = append(ranges, Range{Start: i, End: end})
+		i = end
+	}
+	if len(ranges) == 0 {
+		return nil, ErrEmptyInput
+	}
+	return ranges, nil
+}
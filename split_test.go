@@ -0,0 +1,69 @@
+package gojtp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitDocuments(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxArrayElementCount(2))
+	v := verifier.(Verify)
+	data := []byte(`{"a": 1}{"b": [1, 2]}` + "\n  " + `{"c": {"d": null}} `)
+	ranges, err := v.SplitDocuments(data)
+	if err != nil {
+		t.Fatalf("Expected an nil error Got - %v", err)
+	}
+	expected := []Range{{0, 8}, {8, 21}, {24, 42}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Fatalf("Expected ranges %v Got %v", expected, ranges)
+	}
+	for _, r := range ranges {
+		if d := data[r.Start:r.End]; !IsValid(d) || d[0] != '{' ||
+			d[len(d)-1] != '}' {
+			t.Errorf("Unaligned range %v %q", r, d)
+		}
+	}
+
+	scenarios := []struct {
+		name   string
+		json   string
+		ranges []Range
+		err    error
+	}{
+		{
+			name:   "scalars",
+			json:   `1 "a"true [null]`,
+			ranges: []Range{{0, 1}, {2, 5}, {5, 9}, {10, 16}},
+		},
+		{
+			name:   "unsafe",
+			json:   `[1] [1, 2, 3] [4]`,
+			ranges: []Range{{0, 3}},
+			err:    fmt.Errorf("jtp.maxArrayElementCountReached.Max-[2]-Allowed.Found-[3]"),
+		},
+		{
+			name:   "malformed",
+			json:   `{"a": 1} {"b": }`,
+			ranges: []Range{{0, 8}},
+			err:    fmt.Errorf("jtp.emptyObjectValue.Offset-[6]"),
+		},
+		{
+			name:   "truncated",
+			json:   `[1] [2`,
+			ranges: []Range{{0, 3}},
+			err:    ErrInvalidJSON,
+		},
+		{name: "empty", json: " \n", err: ErrEmptyInput},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ranges, err := v.SplitDocuments([]byte(tc.json))
+			checkResult(t, tc.err == nil, err == nil, tc.err, err)
+			if !reflect.DeepEqual(ranges, tc.ranges) {
+				t.Errorf("Expected ranges %v Got %v", tc.ranges, ranges)
+			}
+		})
+	}
+}
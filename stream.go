@@ -0,0 +1,197 @@
+package gojtp
+
+// stringScanner counts the decoded runes of the strings of a JSON
+// fed in chunks, so a string exceeding its length limit is rejected
+// as soon as the limit is reached without holding the whole string.
+// It also rejects a container exceeding the JSONContainerDepth and a
+// byte which can't be where it is in a valid JSON, like a closing
+// byte not matching the open container.
+//
+// It only tracks the structure needed to tell keys from values,
+// the JSON is validated by the walker once fully read.
+// Its count never exceeds the decoded rune count of the walker.
+type stringScanner struct {
+	verifier *Verify
+	// offset of the next byte fed
+	offset int
+	// stack of the open containers
+	stack     []byte
+	expectKey bool
+
+	inString bool
+	isKey    bool
+	start    int
+	count    int
+	// escape is 1 after a backslash, 2 to 5 within \uXXXX hex digits
+	escape int
+	r      rune
+	// high is set when the previous escape was a high surrogate
+	high bool
+	// literal is set within a number or literal token started at
+	// literalStart
+	literal      bool
+	literalStart int
+	// started is set from the first non whitespace byte, closed once
+	// the top level container is closed.
+	started bool
+	closed  bool
+}
+
+// scan feeds the next chunk of the JSON.
+func (sc *stringScanner) scan(p []byte) error {
+	for k, c := range p {
+		sc.offset++
+		if !sc.inString {
+			if err := sc.structural(c); err != nil {
+				return err
+			}
+			if sc.literal {
+				if err := sc.checkTokenBytes(sc.literalStart); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := sc.checkTokenBytes(sc.start); err != nil {
+			return err
+		}
+		switch {
+		case sc.escape == 1:
+			sc.escape = 0
+			if c == 'u' {
+				sc.escape, sc.r = 2, 0
+				continue
+			}
+			sc.high = false
+			sc.count++
+		case sc.escape > 1:
+			sc.r = sc.r<<4 | hexRune(p[k:k+1])
+			if sc.escape++; sc.escape <= 5 {
+				continue
+			}
+			sc.escape = 0
+			if sc.high && sc.r >= 0xDC00 && sc.r < 0xE000 {
+				// low half of a pair already counted
+				sc.high = false
+				continue
+			}
+			sc.high = sc.r >= 0xD800 && sc.r < 0xDC00
+			sc.count++
+		case c == '\\':
+			sc.escape = 1
+			continue
+		case c == '"':
+			sc.inString = false
+			continue
+		default:
+			sc.high = false
+			// UTF-8 continuation bytes belong to the counted rune
+			if c&0xC0 == 0x80 {
+				continue
+			}
+			sc.count++
+		}
+		if err := sc.checkLength(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structural tracks a byte outside of the strings, failing if it
+// can't be there in a valid JSON or opens a container too deep.
+func (sc *stringScanner) structural(c byte) error {
+	switch c {
+	case ' ', '\t', '\n', '\r':
+		sc.literal = false
+		return nil
+	case '-', '+', '.', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
+		'a', 'e', 'E', 'f', 'l', 'n', 'r', 's', 't', 'u':
+		if sc.closed {
+			return ErrInvalidJSON
+		}
+		if !sc.literal {
+			sc.literal, sc.literalStart = true, sc.offset-1
+		}
+		sc.started = true
+		return nil
+	}
+	sc.literal = false
+	if !sc.started && c != '"' && c != '{' && c != '[' {
+		return firstByteError(c, sc.offset-1)
+	}
+	if sc.closed {
+		return ErrInvalidJSON
+	}
+	sc.started = true
+	switch c {
+	case '"':
+		sc.inString, sc.isKey = true, sc.expectKey
+		sc.start, sc.count, sc.high = sc.offset-1, 0, false
+	case '{', '[':
+		sc.stack = append(sc.stack, c)
+		sc.expectKey = c == '{'
+		// the exempt containers are only known by the walker
+		if d := len(sc.stack); sc.verifier.jsonContainerDepthEnabled &&
+			d > sc.verifier.JSONContainerDepth &&
+			len(sc.verifier.DepthExemptKeys) == 0 {
+			return &LimitError{Kind: containerDepth,
+				Max: sc.verifier.JSONContainerDepth, Found: d,
+				Offset: sc.offset, Container: valueType(c)}
+		}
+	case '}', ']':
+		k := len(sc.stack) - 1
+		if k < 0 || (c == '}') != (sc.stack[k] == '{') {
+			return ErrInvalidJSON
+		}
+		sc.stack = sc.stack[:k]
+		sc.expectKey = false
+		sc.closed = k == 0
+	case ',':
+		if len(sc.stack) == 0 {
+			return ErrInvalidJSON
+		}
+		sc.expectKey = sc.stack[len(sc.stack)-1] == '{'
+	case ':':
+		if len(sc.stack) == 0 {
+			return ErrInvalidJSON
+		}
+		sc.expectKey = false
+	default:
+		return ErrInvalidJSON
+	}
+	return nil
+}
+
+// checkTokenBytes checks the raw bytes of the current token started
+// at start, the reported Found is the first count exceeding the limit.
+func (sc *stringScanner) checkTokenBytes(start int) error {
+	if n := sc.offset - start; sc.verifier.tokenBytesEnabled &&
+		n > sc.verifier.MaxTokenBytes {
+		return newLimitError(tokenBytes, sc.verifier.MaxTokenBytes, n, start)
+	}
+	return nil
+}
+
+// checkLength checks the length of the current string, the reported
+// Found is the first count exceeding the limit.
+func (sc *stringScanner) checkLength() error {
+	// the versioned limits are only known by the walker
+	if sc.verifier.versionedLimits != nil {
+		return nil
+	}
+	depth := len(sc.stack)
+	if sc.isKey {
+		if max, enabled := sc.verifier.keyLengthLimit(depth); enabled &&
+			sc.count > max {
+			return newLimitError(objectKeyValueLength, max, sc.count, sc.start)
+		}
+		return nil
+	}
+	// the relaxed values are only known by the walker
+	if max, enabled := sc.verifier.stringLengthLimit(depth); enabled &&
+		sc.count > max && len(sc.verifier.RelaxedStringLengthKeys) == 0 {
+		return newLimitError(stringValueLength, max, sc.count, sc.start)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package gojtp
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// contextVerifier is implemented by the Verifier that can
+// stop a validation in flight once its context is done.
+type contextVerifier interface {
+	VerifyBytesContext(context.Context, []byte) (bool, error)
+}
+
+type timeoutVerifier struct {
+	verifier Verifier
+	timeout  time.Duration
+}
+
+// WithTimeout returns a Verifier that validates each document under
+// its own context with timeout d, returning context.DeadlineExceeded
+// when the validation of a document takes longer than d.
+//
+// If v cannot be cancelled (it does not implement VerifyBytesContext)
+// the validation runs to the end and the deadline is checked after.
+func WithTimeout(v Verifier, d time.Duration) Verifier {
+	return timeoutVerifier{verifier: v, timeout: d}
+}
+
+// VerifyBytesContext validates the json under a context derived
+// from ctx with the configured timeout.
+func (t timeoutVerifier) VerifyBytesContext(ctx context.Context,
+	json []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	if cv, ok := t.verifier.(contextVerifier); ok {
+		return cv.VerifyBytesContext(ctx, json)
+	}
+	ok, err := t.verifier.VerifyBytes(json)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return false, ctxErr
+	}
+	return ok, err
+}
+
+// VerifyBytes validates the json with the configured timeout.
+func (t timeoutVerifier) VerifyBytes(json []byte) (bool, error) {
+	return t.VerifyBytesContext(context.Background(), json)
+}
+
+// VerifyString validates the json with the configured timeout.
+func (t timeoutVerifier) VerifyString(json string) (bool, error) {
+	return t.VerifyBytes([]byte(json))
+}
+
+// VerifyReader validates the json read from r with the configured
+// timeout, the reading is not cancelled so the deadline is checked
+// after.
+func (t timeoutVerifier) VerifyReader(r io.Reader) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+	ok, err := t.verifier.VerifyReader(r)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return false, ctxErr
+	}
+	return ok, err
+}
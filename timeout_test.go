@@ -0,0 +1,42 @@
+package gojtp
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxContainerDepth(7))
+	huge := append([]byte("["),
+		bytes.Repeat([]byte(`{"a": [1, 2, 3]},`), 100000)...)
+	huge = append(huge, []byte(`{}]`)...)
+
+	t.Run("tiny timeout on huge input", func(t *testing.T) {
+		ok, err := WithTimeout(verifier, time.Nanosecond).VerifyBytes(huge)
+		if ok != false || err != context.DeadlineExceeded {
+			t.Errorf("Expected Ok to Be False and Error of kind"+
+				" context.DeadlineExceeded Got %v %v", ok, err)
+		}
+	})
+
+	t.Run("within timeout", func(t *testing.T) {
+		ok, err := WithTimeout(verifier, time.Minute).VerifyBytes(huge)
+		if ok != true || err != nil {
+			t.Errorf("Expected Ok to Be True and Error nil Got %v %v", ok, err)
+		}
+	})
+}
+
+func TestVerifyBytesContextCancelled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ok, err := Verify{}.VerifyBytesContext(ctx, []byte(`{}`))
+	if ok != false || err != context.Canceled {
+		t.Errorf("Expected Ok to Be False and Error of kind"+
+			" context.Canceled Got %v %v", ok, err)
+	}
+}
@@ -0,0 +1,107 @@
+package gojtp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// TokenType is the type byte of a token of VerifyAndTokenize.
+type TokenType byte
+
+// Token types of VerifyAndTokenize.
+const (
+	TokenObjectStart TokenType = iota + 1
+	TokenObjectEnd
+	TokenArrayStart
+	TokenArrayEnd
+	// TokenKey, TokenString and TokenNumber are followed by a payload.
+	TokenKey
+	TokenString
+	TokenNumber
+	TokenTrue
+	TokenFalse
+	TokenNull
+)
+
+// tokenizeHandler writes the events of a walk as tokens to w.
+type tokenizeHandler struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+// write writes the token of type t with the payload if any.
+func (h *tokenizeHandler) write(t TokenType, payload []byte) error {
+	if err := h.w.WriteByte(byte(t)); err != nil {
+		return err
+	}
+	if t < TokenKey || t > TokenNumber {
+		return nil
+	}
+	var n [binary.MaxVarintLen64]byte
+	if _, err := h.w.Write(n[:binary.PutUvarint(n[:],
+		uint64(len(payload)))]); err != nil {
+		return err
+	}
+	_, err := h.w.Write(payload)
+	return err
+}
+
+func (h *tokenizeHandler) begin(c byte, i int) error {
+	if c == '{' {
+		return h.write(TokenObjectStart, nil)
+	}
+	return h.write(TokenArrayStart, nil)
+}
+
+func (h *tokenizeHandler) end(c byte, i int) error {
+	if c == '}' {
+		return h.write(TokenObjectEnd, nil)
+	}
+	return h.write(TokenArrayEnd, nil)
+}
+
+func (h *tokenizeHandler) key(data []byte, startIndex, endIndex int) error {
+	h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+	return h.write(TokenKey, h.buf)
+}
+
+func (h *tokenizeHandler) value(data []byte, startIndex, endIndex int) error {
+	switch data[startIndex] {
+	case '"':
+		h.buf = appendDecodedString(h.buf[:0], data, startIndex, endIndex)
+		return h.write(TokenString, h.buf)
+	case 't':
+		return h.write(TokenTrue, nil)
+	case 'f':
+		return h.write(TokenFalse, nil)
+	case 'n':
+		return h.write(TokenNull, nil)
+	}
+	return h.write(TokenNumber, data[startIndex:endIndex])
+}
+
+// VerifyAndTokenize verifies the json as VerifyBytes does and writes
+// its tokens to w, so a consumer reads them without scanning the json
+// again. Each token is its TokenType byte, followed for TokenKey,
+// TokenString and TokenNumber by the length of its payload as an
+// unsigned varint, see encoding/binary, and the payload: the decoded
+// UTF-8 content of a key or string, without quotes, or the number as
+// written. The containers are delimited by their start and end
+// tokens, the members of an object are a TokenKey followed by the
+// tokens of its value, e.g. {"a": [1]} is
+//
+//	0x01 0x05 0x01 'a' 0x03 0x07 0x01 '1' 0x04 0x02
+//
+// The tokens are buffered and flushed before returning, a failing
+// write stops the verification and is returned. When the json fails,
+// the tokens cover the json until the failure and must be discarded.
+func (v Verify) VerifyAndTokenize(json []byte, w io.Writer) (bool, error) {
+	h := &tokenizeHandler{w: bufio.NewWriter(w)}
+	s := state{verifier: v, h: h}
+	ok, err := s.verify(json)
+	if ferr := h.w.Flush(); ferr != nil && err == nil {
+		return false, ferr
+	}
+	return ok, err
+}
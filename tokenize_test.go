@@ -0,0 +1,136 @@
+package gojtp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// decodeTokens decodes the next value of a VerifyAndTokenize stream,
+// with json.Number numbers.
+func decodeTokens(r *bufio.Reader) (interface{}, error) {
+	t, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	payload := func() ([]byte, error) {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n)
+		_, err = io.ReadFull(r, b)
+		return b, err
+	}
+	switch TokenType(t) {
+	case TokenObjectStart:
+		obj := map[string]interface{}{}
+		for {
+			t, err := r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if TokenType(t) == TokenObjectEnd {
+				return obj, nil
+			}
+			if TokenType(t) != TokenKey {
+				return nil, fmt.Errorf("unexpected token %d", t)
+			}
+			key, err := payload()
+			if err != nil {
+				return nil, err
+			}
+			if obj[string(key)], err = decodeTokens(r); err != nil {
+				return nil, err
+			}
+		}
+	case TokenArrayStart:
+		arr := []interface{}{}
+		for {
+			if b, err := r.Peek(1); err == nil &&
+				TokenType(b[0]) == TokenArrayEnd {
+				_, _ = r.ReadByte()
+				return arr, nil
+			}
+			v, err := decodeTokens(r)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+	case TokenString:
+		s, err := payload()
+		return string(s), err
+	case TokenNumber:
+		n, err := payload()
+		return json.Number(n), err
+	case TokenTrue:
+		return true, nil
+	case TokenFalse:
+		return false, nil
+	case TokenNull:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unexpected token %d", t)
+}
+
+func TestVerifyAndTokenize(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(20))
+	v := verifier.(Verify)
+	scenarios := []string{
+		`{"a": [1, -2.5e3, "xé\n"], "b": {"c": null, "d": true},
+			"e": [], "f": {}, "g": false}`,
+		`[[[]], {"k\"ey": "v"}]`,
+		`"top"`,
+		`42`,
+	}
+	for _, src := range scenarios {
+		t.Run(src, func(t *testing.T) {
+			var buf bytes.Buffer
+			ok, err := v.VerifyAndTokenize([]byte(src), &buf)
+			checkResult(t, true, ok, nil, err)
+			r := bufio.NewReader(&buf)
+			got, err := decodeTokens(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.ReadByte(); err != io.EOF {
+				t.Errorf("Expected the end of the tokens")
+			}
+			d := json.NewDecoder(bytes.NewReader([]byte(src)))
+			d.UseNumber()
+			var expected interface{}
+			if err := d.Decode(&expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %v Got %v", expected, got)
+			}
+		})
+	}
+
+	t.Run("documented example", func(t *testing.T) {
+		var buf bytes.Buffer
+		ok, err := v.VerifyAndTokenize([]byte(`{"a": [1]}`), &buf)
+		checkResult(t, true, ok, nil, err)
+		expected := []byte{0x01, 0x05, 0x01, 'a', 0x03, 0x07, 0x01, '1',
+			0x04, 0x02}
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("Expected %x Got %x", expected, buf.Bytes())
+		}
+	})
+
+	t.Run("unsafe", func(t *testing.T) {
+		ok, err := v.VerifyAndTokenize(
+			[]byte(`["this string is far too long"]`), io.Discard)
+		checkResult(t, false, ok, fmt.Errorf(
+			"jtp.maxStringValueLengthReached.Max-[20]-Allowed.Found-[27]"),
+			err)
+	})
+}
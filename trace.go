@@ -0,0 +1,85 @@
+package gojtp
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+)
+
+// traceHandler writes each event of a walk as a NDJSON line to w.
+type traceHandler struct {
+	w     *bufio.Writer
+	depth int
+	line  []byte
+}
+
+// write writes the event line, the value is the JSON token as written
+// with its raw control characters escaped, to keep one line per event.
+func (h *traceHandler) write(event string, token []byte, i int) error {
+	const hex = "0123456789abcdef"
+	b := append(h.line[:0], `{"event":"`...)
+	b = append(b, event...)
+	b = append(b, `","value":`...)
+	for _, c := range token {
+		switch {
+		case c == '\n':
+			b = append(b, '\\', 'n')
+		case c == '\t':
+			b = append(b, '\\', 't')
+		case c < ' ':
+			b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+		default:
+			b = append(b, c)
+		}
+	}
+	b = append(b, `,"depth":`...)
+	b = strconv.AppendInt(b, int64(h.depth), 10)
+	b = append(b, `,"offset":`...)
+	b = strconv.AppendInt(b, int64(i), 10)
+	b = append(b, '}', '\n')
+	h.line = b
+	_, err := h.w.Write(b)
+	return err
+}
+
+func (h *traceHandler) begin(c byte, i int) error {
+	h.depth++
+	return h.write("begin", []byte{'"', c, '"'}, i)
+}
+
+func (h *traceHandler) end(c byte, i int) error {
+	err := h.write("end", []byte{'"', c, '"'}, i)
+	h.depth--
+	return err
+}
+
+func (h *traceHandler) key(data []byte, startIndex, endIndex int) error {
+	return h.write("key", data[startIndex:endIndex], startIndex)
+}
+
+func (h *traceHandler) value(data []byte, startIndex, endIndex int) error {
+	return h.write("value", data[startIndex:endIndex], startIndex)
+}
+
+// VerifyTrace verifies the json as VerifyBytes does and writes each
+// event of the walk to w as a NDJSON line, for debugging, e.g.
+//
+//	{"event":"key","value":"targets","depth":1,"offset":1}
+//
+// The events are begin and end for the containers, with the value "{"
+// or "[" and "}" or "]", key and value, with the value JSON token as
+// written. The depth is the number of containers open, the offset the
+// byte offset of the token in the json.
+//
+// The lines are buffered and flushed before returning, a failing
+// write stops the verification and is returned. When the json fails,
+// the trace covers the events walked until the failure.
+func (v Verify) VerifyTrace(json []byte, w io.Writer) (bool, error) {
+	h := &traceHandler{w: bufio.NewWriter(w)}
+	s := state{verifier: v, h: h}
+	ok, err := s.verify(json)
+	if ferr := h.w.Flush(); ferr != nil && err == nil {
+		return false, ferr
+	}
+	return ok, err
+}
@@ -0,0 +1,47 @@
+package gojtp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestVerifyTrace(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	ok, err := Verify{}.VerifyTrace([]byte(`{"targets": [1, "a\tb"], "b": {}}`),
+		&buf)
+	checkResult(t, true, ok, nil, err)
+	expected := `{"event":"begin","value":"{","depth":1,"offset":0}
+{"event":"key","value":"targets","depth":1,"offset":1}
+{"event":"begin","value":"[","depth":2,"offset":12}
+{"event":"value","value":1,"depth":2,"offset":13}
+{"event":"value","value":"a\tb","depth":2,"offset":16}
+{"event":"end","value":"]","depth":2,"offset":22}
+{"event":"key","value":"b","depth":1,"offset":25}
+{"event":"begin","value":"{","depth":2,"offset":30}
+{"event":"end","value":"}","depth":2,"offset":31}
+{"event":"end","value":"}","depth":1,"offset":32}
+`
+	if buf.String() != expected {
+		t.Errorf("Expected trace\n%s Got\n%s", expected, buf.String())
+	}
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		if !json.Valid(line) {
+			t.Errorf("Expected a valid json line Got %s", line)
+		}
+	}
+
+	verifier, _ := New(WithMaxArrayElementCount(1))
+	buf.Reset()
+	ok, err = verifier.(Verify).VerifyTrace([]byte(`[1, 2]`), &buf)
+	checkResult(t, false, ok, errors.New(
+		"jtp.maxArrayElementCountReached.Max-[1]-Allowed.Found-[2]"), err)
+	expected = `{"event":"begin","value":"[","depth":1,"offset":0}
+{"event":"value","value":1,"depth":1,"offset":1}
+`
+	if buf.String() != expected {
+		t.Errorf("Expected trace\n%s Got\n%s", expected, buf.String())
+	}
+}
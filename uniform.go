@@ -0,0 +1,170 @@
+package gojtp
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// uniformRule requires the values of the objects under key to be
+// the JSON literal value.
+type uniformRule struct {
+	key   string
+	value []byte
+}
+
+// WithUniformObjectValues Option
+// Specifies that every value of an object under key, like a set
+// {"a": true, "b": true} in "tags", must be the JSON scalar
+// requireValue, e.g. "true". The values are compared as written,
+// so a string escaped differently doesn't match.
+// It can be passed multiple times, the first matching key applies.
+func WithUniformObjectValues(key string, requireValue string) Option {
+	return func(verifier *Verify) error {
+		value := []byte(requireValue)
+		if !IsValid(value) || len(bytes.TrimSpace(value)) != len(value) ||
+			value[0] == '{' || value[0] == '[' {
+			return fmt.Errorf("jtp: uniform object value must be"+
+				" a JSON scalar %q", requireValue)
+		}
+		verifier.uniformValues = append(verifier.uniformValues,
+			uniformRule{key: key, value: value})
+		return nil
+	}
+}
+
+// uniformHandler checks the values of the objects under the keys of
+// the rules, forwarding the events to next if set.
+type uniformHandler struct {
+	forwarder
+	rules []uniformRule
+	// frames has for each open container the index of its rule,
+	// -1 for none.
+	frames []int
+	// pending is the rule of the last key, -1 for none.
+	pending int
+}
+
+// check fails if the value at i is in an object with a rule.
+func (h *uniformHandler) check(value []byte, i int) error {
+	if len(h.frames) == 0 {
+		return nil
+	}
+	if rule := h.frames[len(h.frames)-1]; rule >= 0 &&
+		!bytes.Equal(value, h.rules[rule].value) {
+		return fmt.Errorf("jtp.nonUniformObjectValue.Key-[%s].Offset-[%d]",
+			h.rules[rule].key, i)
+	}
+	return nil
+}
+
+func (h *uniformHandler) begin(c byte, i int) error {
+	if err := h.check(nil, i); err != nil {
+		return err
+	}
+	rule := -1
+	if c == '{' {
+		rule = h.pending
+	}
+	h.frames = append(h.frames, rule)
+	h.pending = -1
+	return h.forwarder.begin(c, i)
+}
+
+func (h *uniformHandler) end(c byte, i int) error {
+	h.frames = h.frames[:len(h.frames)-1]
+	return h.forwarder.end(c, i)
+}
+
+func (h *uniformHandler) key(data []byte, startIndex, endIndex int) error {
+	h.pending = -1
+	for j, rule := range h.rules {
+		if stringEquals(data, startIndex, endIndex, rule.key) {
+			h.pending = j
+			break
+		}
+	}
+	return h.forwarder.key(data, startIndex, endIndex)
+}
+
+func (h *uniformHandler) value(data []byte, startIndex, endIndex int) error {
+	h.pending = -1
+	if err := h.check(data[startIndex:endIndex], startIndex); err != nil {
+		return err
+	}
+	return h.forwarder.value(data, startIndex, endIndex)
+}
+
+// WithUniformObjectKeysInArrays Option
+// Specifies that the objects of an array must all have the same keys
+// as its first object, in any order, like the rows of a table,
+// failing with jtp.inconsistentObjectKeys.Path-[/rows/3] on the first
+// object with a missing or extra key. The other elements of the
+// array are ignored, see WithHomogeneousArrays.
+func WithUniformObjectKeysInArrays() Option {
+	return func(verifier *Verify) error {
+		verifier.UniformObjectKeysInArrays = true
+		return nil
+	}
+}
+
+// keySetHandler checks the objects of each array have the keys of
+// its first object, forwarding the events to next if set.
+type keySetHandler struct {
+	pathForwarder
+	// sets has for each open array the keys of its first object, nil
+	// until it's closed, and for each open object of an array its
+	// keys, nil for the other objects.
+	sets []map[string]struct{}
+}
+
+// sameKeys reports if a and b have the same keys.
+func sameKeys(a, b map[string]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// inArray reports if the innermost open container is an array.
+func (h *keySetHandler) inArray() bool {
+	return h.n > 0 && h.pathTracker.frames[h.n-1].array
+}
+
+func (h *keySetHandler) begin(c byte, i int) error {
+	var keys map[string]struct{}
+	if c == '{' && h.inArray() {
+		keys = make(map[string]struct{})
+	}
+	h.pathTracker.begin(c)
+	h.sets = append(h.sets, keys)
+	return h.forwarder.begin(c, i)
+}
+
+func (h *keySetHandler) end(c byte, i int) error {
+	h.pathTracker.end()
+	keys := h.sets[len(h.sets)-1]
+	h.sets = h.sets[:len(h.sets)-1]
+	if c == '}' && keys != nil {
+		first := &h.sets[len(h.sets)-1]
+		if *first == nil {
+			*first = keys
+		} else if !sameKeys(*first, keys) {
+			return fmt.Errorf("jtp.inconsistentObjectKeys.Path-[%s]",
+				h.appendPointer(nil))
+		}
+	}
+	return h.forwarder.end(c, i)
+}
+
+func (h *keySetHandler) key(data []byte, startIndex, endIndex int) error {
+	h.pathTracker.key(data, startIndex, endIndex)
+	if keys := h.sets[len(h.sets)-1]; keys != nil {
+		keys[string(h.pathTracker.frames[h.n-1].key)] = struct{}{}
+	}
+	return h.forwarder.key(data, startIndex, endIndex)
+}
@@ -0,0 +1,107 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUniformObjectValues(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithUniformObjectValues("tags", "true"))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "set of true",
+			json: `{"tags": {"a": true, "b": true}, "other": {"c": false}}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "stray false",
+			json: `{"tags": {"a": true, "b": false, "c": true}}`,
+			err:  fmt.Errorf("jtp.nonUniformObjectValue.Key-[tags].Offset-[26]"),
+			ok:   false,
+		},
+		{
+			name: "nested container",
+			json: `[{"tags": {"a": true, "b": {}}}]`,
+			err:  fmt.Errorf("jtp.nonUniformObjectValue.Key-[tags].Offset-[27]"),
+			ok:   false,
+		},
+		{
+			name: "not an object",
+			json: `{"tags": [false], "x": {"tags": {}}}`,
+			err:  nil,
+			ok:   true,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+	for _, value := range []string{"", "{}", " true", "tru"} {
+		if _, err := New(WithUniformObjectValues("tags", value)); err == nil {
+			t.Errorf("Expected an error for the value %q", value)
+		}
+	}
+}
+
+func TestUniformObjectKeysInArrays(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithUniformObjectKeysInArrays())
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "consistent rows",
+			json: `{"rows": [{"id": 1, "name": "a"}, {"name": "b", "id": 2}]}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "extra key",
+			json: `{"rows": [{"id": 1}, {"id": 2}, {"id": 3, "x": 0}]}`,
+			err:  fmt.Errorf("jtp.inconsistentObjectKeys.Path-[/rows/2]"),
+			ok:   false,
+		},
+		{
+			name: "missing key",
+			json: `[{"id": 1, "name": "a"}, {"id": 2, "id": 3}]`,
+			err:  fmt.Errorf("jtp.inconsistentObjectKeys.Path-[/1]"),
+			ok:   false,
+		},
+		{
+			name: "nested arrays",
+			json: `[{"a": [{"x": 1}, {"x": 2}]}, 7, {"a": [{"y": 1}]}]`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "nested inconsistency",
+			json: `[{"a": [{"x": 1}, {"y": 2}]}]`,
+			err:  fmt.Errorf("jtp.inconsistentObjectKeys.Path-[/0/a/1]"),
+			ok:   false,
+		},
+		{
+			name: "empty first object",
+			json: `[{}, {"a": 1}]`,
+			err:  fmt.Errorf("jtp.inconsistentObjectKeys.Path-[/1]"),
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+}
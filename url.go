@@ -0,0 +1,32 @@
+package gojtp
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// WithURLKeys Option
+// Specifies the keys whose string value must be an absolute http or
+// https URL with a host, or only https if requireHTTPS, e.g. to reject
+// a javascript: URL stored under an endpoint key, with
+// jtp.invalidURLValue.Key-[endpoint]. Only string values directly
+// under the keys are checked.
+func WithURLKeys(requireHTTPS bool, keys ...string) Option {
+	return func(verifier *Verify) error {
+		verifier.URLKeys = append(verifier.URLKeys, keys...)
+		verifier.RequireHTTPSURLs = verifier.RequireHTTPSURLs || requireHTTPS
+		return nil
+	}
+}
+
+// validateURL checks the valid string value span under the URL key.
+func validateURL(data []byte, startIndex, endIndex int, key string,
+	requireHTTPS bool) error {
+	u, err := url.Parse(string(appendDecodedString(nil, data,
+		startIndex, endIndex)))
+	if err == nil && u.Host != "" && (u.Scheme == "https" ||
+		u.Scheme == "http" && !requireHTTPS) {
+		return nil
+	}
+	return fmt.Errorf("jtp.invalidURLValue.Key-[%s]", key)
+}
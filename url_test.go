@@ -0,0 +1,48 @@
+package gojtp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestURLKeys(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithURLKeys(false, "endpoint", "callback"))
+	https, _ := New(WithURLKeys(true, "endpoint"))
+	scenarios := []struct {
+		name     string
+		json     string
+		err      error
+		httpsErr error
+	}{
+		{name: "https", json: `{"endpoint": "https://httpbin.org/get?a=1"}`},
+		{
+			name:     "http",
+			json:     `{"endpoint": "http://httpbin.org/get"}`,
+			httpsErr: fmt.Errorf("jtp.invalidURLValue.Key-[endpoint]"),
+		},
+		{
+			name:     "javascript",
+			json:     `{"a": 1, "endpoint": "javascript:alert(1)"}`,
+			err:      fmt.Errorf("jtp.invalidURLValue.Key-[endpoint]"),
+			httpsErr: fmt.Errorf("jtp.invalidURLValue.Key-[endpoint]"),
+		},
+		{
+			name: "relative",
+			json: `[{"callback": "/hook"}]`,
+			err:  fmt.Errorf("jtp.invalidURLValue.Key-[callback]"),
+		},
+		{
+			name: "not a string or not under the key",
+			json: `{"endpoint": {"name": "javascript:alert(1)"}, "x": "y"}`,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.err == nil, ok, tc.err, err)
+			ok, err = https.VerifyString(tc.json)
+			checkResult(t, tc.httpsErr == nil, ok, tc.httpsErr, err)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package gojtp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownSchemaVersion denotes a JSON without the version key
+// of WithVersionedLimits or with a version without limits.
+var ErrUnknownSchemaVersion = errors.New("jtp.unknownSchemaVersion")
+
+// WithVersionedLimits Option
+// Specifies the key and string value length limits per version of
+// the document, the value of key in the top level object, like
+// "schema_version", a string compared once decoded or another scalar
+// compared as written, e.g. "2" for 2. The version is found by a scan
+// of the top level entries before the walk, a JSON without it or with
+// a version missing from byVersion fails with ErrUnknownSchemaVersion.
+// A zero limit of the version keeps the WithMaxObjectKeyLength or
+// WithMaxStringLength limit.
+func WithVersionedLimits(key string, byVersion map[string]Limits) Option {
+	return func(verifier *Verify) error {
+		versions := make(map[string]Limits, len(byVersion))
+		for version, l := range byVersion {
+			if l.ObjectKeyLength < 0 || l.StringValueLen < 0 {
+				return fmt.Errorf("jtp: version %q limits cannot be"+
+					" negative %+v", version, l)
+			}
+			versions[version] = l
+		}
+		verifier.versionKey = key
+		verifier.versionedLimits = versions
+		return nil
+	}
+}
+
+// applyVersionLimits sets the limits of the version of the json.
+func (v *Verify) applyVersionLimits(json []byte) error {
+	start, end, found := topLevelValue(json, v.versionKey)
+	if !found || json[start] == '{' || json[start] == '[' {
+		return ErrUnknownSchemaVersion
+	}
+	version := json[start:end]
+	if json[start] == '"' {
+		version = appendDecodedString(nil, json, start, end)
+	}
+	l, ok := v.versionedLimits[string(version)]
+	if !ok {
+		return ErrUnknownSchemaVersion
+	}
+	if l.ObjectKeyLength > 0 {
+		v.ObjectKeyLength = l.ObjectKeyLength
+		v.objectKeyLengthEnabled = true
+	}
+	if l.StringValueLen > 0 {
+		v.StringValueLen = l.StringValueLen
+		v.stringLenEnabled = true
+	}
+	return nil
+}
+
+// topLevelValue returns the span of the value of the first key entry
+// of the top level object of json, skipping the other top level
+// values without checks. found is false if json is not an object
+// with the key or is malformed before it.
+func topLevelValue(json []byte, key string) (start, end int,
+	found bool) {
+	s := state{verifier: Verify{}}
+	i := skipSpace(json, 0)
+	if i == len(json) || json[i] != '{' {
+		return 0, 0, false
+	}
+	for i = skipSpace(json, i+1); i < len(json) && json[i] == '"'; {
+		keyEnd, ok, _ := validany(json, i, &s)
+		if !ok {
+			return 0, 0, false
+		}
+		colon := skipSpace(json, keyEnd)
+		if colon == len(json) || json[colon] != ':' {
+			return 0, 0, false
+		}
+		start = skipSpace(json, colon+1)
+		if end, ok, _ = validany(json, start, &s); !ok {
+			return 0, 0, false
+		}
+		if stringEquals(json, i, keyEnd, key) {
+			return start, end, true
+		}
+		i = skipSpace(json, end)
+		if i == len(json) || json[i] != ',' {
+			return 0, 0, false
+		}
+		i = skipSpace(json, i+1)
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,105 @@
+package gojtp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVersionedLimits(t *testing.T) {
+	t.Parallel()
+	verifier, _ := New(WithMaxStringLength(50),
+		WithVersionedLimits("schema_version", map[string]Limits{
+			"1": {StringValueLen: 5},
+			"2": {StringValueLen: 10},
+			"k": {ObjectKeyLength: 4},
+		}))
+	scenarios := []struct {
+		name string
+		json string
+		err  error
+		ok   bool
+	}{
+		{
+			name: "version 1",
+			json: `{"schema_version": "1", "name": "abcde"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "version 1 long string",
+			json: `{"name": "abcdefgh", "schema_version": "1"}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[5]-Allowed.Found-[8]"),
+			ok:   false,
+		},
+		{
+			name: "version 2",
+			json: `{"schema_version": "2", "name": "abcdefghijk"}`,
+			err:  fmt.Errorf("jtp.maxStringValueLengthReached.Max-[10]-Allowed.Found-[11]"),
+			ok:   false,
+		},
+		{
+			name: "key length version",
+			json: `{"schema_version": "k"}`,
+			err:  fmt.Errorf("jtp.maxKeyLengthReached.Max-[4]-Allowed.Found-[14]"),
+			ok:   false,
+		},
+		{
+			name: "numeric version",
+			json: `{"v": {"schema_version": 3}, "schema_version": 2, "s": "abcdefgh"}`,
+			err:  nil,
+			ok:   true,
+		},
+		{
+			name: "unknown version",
+			json: `{"schema_version": "3"}`,
+			err:  ErrUnknownSchemaVersion,
+			ok:   false,
+		},
+		{
+			name: "nested version only",
+			json: `{"data": {"schema_version": "1"}}`,
+			err:  ErrUnknownSchemaVersion,
+			ok:   false,
+		},
+		{
+			name: "empty object",
+			json: `{}`,
+			err:  ErrUnknownSchemaVersion,
+			ok:   false,
+		},
+	}
+	for _, tc := range scenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := verifier.VerifyString(tc.json)
+			checkResult(t, tc.ok, ok, tc.err, err)
+		})
+	}
+
+	// the streaming verifiers leave the versioned limits to the walk
+	streamed, _ := New(WithMaxStringLength(3), WithMaxObjectKeyLength(3),
+		WithVersionedLimits("v", map[string]Limits{
+			"2": {StringValueLen: 10, ObjectKeyLength: 10},
+		}))
+	for _, json := range []string{`{"v":"2","a":"xxxxxxx"}`,
+		`{"v":"2","abcdefg":"x"}`} {
+		ok, err := streamed.(Verify).VerifyReader(strings.NewReader(json))
+		checkResult(t, true, ok, nil, err)
+		r := streamed.(Verify).NewResumable()
+		for i := range json {
+			if err := r.Write([]byte{json[i]}); err != nil {
+				t.Fatalf("Expected an nil error Got - %v", err)
+			}
+		}
+		ok, err = r.Finish()
+		checkResult(t, true, ok, nil, err)
+	}
+	ok, err := streamed.(Verify).VerifyReader(strings.NewReader(
+		`{"v":"2","a":"xxxxxxxxxxx"}`))
+	checkResult(t, false, ok, fmt.Errorf(
+		"jtp.maxStringValueLengthReached.Max-[10]-Allowed.Found-[11]"), err)
+	if _, err := New(WithVersionedLimits("v", map[string]Limits{
+		"1": {StringValueLen: -1}})); err == nil {
+		t.Errorf("Expected an error for a negative version limit")
+	}
+}
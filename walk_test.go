@@ -0,0 +1,108 @@
+package gojtp
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+	strict, _ := New(WithMaxArrayElementCount(6), WithMaxContainerDepth(4),
+		WithMaxObjectKeyLength(10), WithMaxStringLength(20),
+		WithMaxObjectEntryCount(4), WithMaxTotalObjects(4),
+		WithMaxContainerCount(6), WithMaxArrayRank(3),
+		WithMaxLeadingScalarsInArray(3), WithArrayUnitBudget(10, 3),
+		WithMaxSubtreeEntries(5), WithMaxArrayLiteralBytes(40),
+		WithMaxColonSurroundingWhitespace(2), WithRequireStructuredTopLevel())
+	// results of the recursive walk, for the default and the strict verifier
+	scenarios := []struct {
+		json       string
+		outi       int
+		ok         bool
+		err        string
+		strictOuti int
+		strictOk   bool
+		strictErr  string
+	}{
+		{``, 0, false, "jtp.EmptyInput", 0, false, "jtp.EmptyInput"},
+		{`   `, 3, false, "jtp.EmptyInput", 3, false, "jtp.EmptyInput"},
+		{`{}`, 2, true, "<nil>", 2, true, "<nil>"},
+		{`[]`, 2, true, "<nil>", 2, true, "<nil>"},
+		{` [ ] `, 5, true, "<nil>", 5, true, "<nil>"},
+		{`{"a": 1}`, 8, true, "<nil>", 8, true, "<nil>"},
+		{`[1, "a", true, false, null, -1.5e3, {"b": [2, {}]}]`, 51, true, "<nil>", 36, false, "jtp.maxArrayElementCountReached.Max-[6]-Allowed.Found-[7]"},
+		{`{"a": {"b": {"c": [1, [2, [3]]]}}}`, 34, true, "<nil>", 23, false, "jtp.maxContainerDepthReached.Type-[array].Max-[4]-Allowed.Found-[5]"},
+		{`[[[[[[1]]]]]]`, 13, true, "<nil>", 3, false, "jtp.maxArrayRankReached.Max-[3]-Allowed.Found-[4]"},
+		{`{"a": [1, 2, 3, 4, 5, 6, 7, 8]}`, 31, true, "<nil>", 25, false, "jtp.maxArrayElementCountReached.Max-[6]-Allowed.Found-[7]"},
+		{`{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}`, 40, true, "<nil>", 36, false, "jtp.maxObjectEntryCountReached.Max-[4]-Allowed.Found-[5]"},
+		{`{"abcdefghijk": 1}`, 18, true, "<nil>", 14, false, "jtp.maxKeyLengthReached.Max-[10]-Allowed.Found-[11]"},
+		{`["abcdefghijklmnopqrstu"]`, 25, true, "<nil>", 24, false, "jtp.maxStringValueLengthReached.Max-[20]-Allowed.Found-[21]"},
+		{`[1, 2`, 5, false, "<nil>", 5, false, "<nil>"},
+		{`[1, 2,]`, 6, false, "jtp.trailingComma.Offset-[5]", 6, false, "jtp.trailingComma.Offset-[5]"},
+		{`[1 2]`, 3, false, "<nil>", 3, false, "<nil>"},
+		{`{"a" 1}`, 5, false, "<nil>", 5, false, "<nil>"},
+		{`{"a": 1,}`, 8, false, "jtp.trailingComma.Offset-[7]", 8, false, "jtp.trailingComma.Offset-[7]"},
+		{`{"a": 1 "b": 2}`, 8, false, "<nil>", 8, false, "<nil>"},
+		{`{1: 2}`, 1, false, "<nil>", 1, false, "<nil>"},
+		{`{"a": }`, 6, false, "jtp.emptyObjectValue.Offset-[6]", 6, false, "jtp.emptyObjectValue.Offset-[6]"},
+		{`[tru]`, 4, false, "<nil>", 4, false, "<nil>"},
+		{`[nul, 1]`, 4, false, "<nil>", 4, false, "<nil>"},
+		{`["\x"]`, 3, false, "<nil>", 3, false, "<nil>"},
+		{`["a` + "\n" + `"]`, 3, false, "<nil>", 3, false, "<nil>"},
+		{`[01]`, 2, false, "<nil>", 2, false, "<nil>"},
+		{`[1.]`, 3, false, "<nil>", 3, false, "<nil>"},
+		{`[-]`, 2, false, "<nil>", 2, false, "<nil>"},
+		{`[1e]`, 3, false, "<nil>", 3, false, "<nil>"},
+		{`{"a": [}`, 7, false, "<nil>", 7, false, "<nil>"},
+		{`{"a": [1, {"b": ]}`, 16, false, "<nil>", 16, false, "<nil>"},
+		{`[{"a": 1}, {"a": 1}, {"a": 1}]`, 30, true, "<nil>", 30, true, "<nil>"},
+		{`"bare"`, 6, true, "<nil>", 0, false, "jtp.bareTopLevelValue"},
+		{`123`, 3, true, "<nil>", 0, false, "jtp.bareTopLevelValue"},
+		{`<xml>`, 0, false, "jtp.notJSON.FirstByte-[<]", 0, false, "jtp.notJSON.FirstByte-[<]"},
+		{`{"a": 1} x`, 9, false, "<nil>", 9, false, "<nil>"},
+		{`[{}, [], {"x": []}]`, 19, true, "<nil>", 19, true, "<nil>"},
+		{`{"a": "b"   :}`, 12, false, "<nil>", 12, false, "<nil>"},
+		{`[[1], [2, [3, [4, [5]]]]]`, 25, true, "<nil>", 14, false, "jtp.maxArrayRankReached.Max-[3]-Allowed.Found-[4]"},
+		{`{"key": "value", "nested": {"deep": {"deeper": {"deepest": 1}}}}`, 64, true, "<nil>", 64, true, "<nil>"},
+		{`[{"a": {"b": {"c": {"d": 1}}}}]`, 31, true, "<nil>", 20, false, "jtp.maxContainerDepthReached.Type-[object].Max-[4]-Allowed.Found-[5]"},
+		{"{\"a\":\t\n 1 ,\r\n \"b\" : [ 1 , 2 ] }", 31, true, "<nil>", 8, false, "jtp.maxColonWhitespaceReached.Max-[2]-Allowed.Found-[3]"},
+		{`[1, 2, 3, 4, {}]`, 16, true, "<nil>", 13, false, "jtp.tooManyLeadingScalars.Max-[3]-Allowed.Found-[4]"},
+		{`[{}, {}, {}, {}]`, 16, true, "<nil>", 13, false, "jtp.arrayUnitBudgetExceeded.Max-[10]-Allowed.Found-[12]"},
+		{`["abcdefghij", "abcdefghij", "abcdefghij"]`, 42, true, "<nil>", 41, false, "jtp.maxArrayLiteralBytesReached.Max-[40]-Allowed.Found-[42]"},
+		{`{"a": {"b": 1, "c": 2}, "d": {"e": 1, "f": 2}}`, 46, true, "<nil>", 41, false, "jtp.maxSubtreeEntriesReached.Max-[5]-Allowed.Found-[6]"},
+		{`[{}, [{}, {}, {}], {}]`, 22, true, "<nil>", 19, false, "jtp.maxTotalObjectsReached.Max-[4]-Allowed.Found-[5]"},
+		{`[[], [], [], [], [], []]`, 24, true, "<nil>", 21, false, "jtp.maxContainerCountReached.Max-[6]-Allowed.Found-[7]"},
+	}
+	for _, tc := range scenarios {
+		for _, v := range []struct {
+			verifier Verify
+			outi     int
+			ok       bool
+			err      string
+		}{
+			{Verify{}, tc.outi, tc.ok, tc.err},
+			{strict.(Verify), tc.strictOuti, tc.strictOk, tc.strictErr},
+		} {
+			s := state{verifier: v.verifier}
+			outi, ok, err := isValidJSON([]byte(tc.json), 0, &s)
+			if outi != v.outi || ok != v.ok || fmt.Sprint(err) != v.err {
+				t.Errorf("%q Expected {%d, %v, %s} Got {%d, %v, %v}", tc.json,
+					v.outi, v.ok, v.err, outi, ok, err)
+			}
+		}
+	}
+}
+
+func TestWalkDeepNesting(t *testing.T) {
+	// a goroutine stack of 1MB overflowed with the recursive walk
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+	const depth = 1000000
+	json := strings.Repeat("[", depth) + strings.Repeat("]", depth)
+	verifier, _ := New()
+	ok, err := verifier.VerifyString(json)
+	checkResult(t, true, ok, nil, err)
+	ok, err = verifier.VerifyString(json[:len(json)-1])
+	checkResult(t, false, ok, ErrInvalidJSON, err)
+}